@@ -50,6 +50,7 @@ func (r *Session) ProfileAttempt()                      { r.store.Inc("profile_a
 func (r *Session) ProfileComplete()                     { r.store.Inc("profiles_completed", 1) }
 func (r *Session) AddScreenshots(n int)                 { r.store.Inc("screenshots", int64(n)) }
 func (r *Session) RecordAction(a domain.AppActionType)  { r.store.Inc("action."+string(a), 1) }
+func (r *Session) RecordMatch()                         { r.store.Inc("matches", 1) }
 func (r *Session) Inc(name string, n int64)             { r.store.Inc(name, n) }
 func (r *Session) Observe(name string, d time.Duration) { r.store.Observe(name, d) }
 
@@ -3,40 +3,53 @@ package bumble
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/vd09-projects/swipeassist/apps/engine"
 	"github.com/vd09-projects/swipeassist/domain"
 )
 
-const BumbleProfileFormat = "Profile_%s_%d"
-
 type Adapter struct {
-	startTime    time.Time
-	profileIndex int
-	S            Selectors
+	S        Selectors
+	EntryURL string
+
+	// MatchWait is how long HandleMatch watches for the match overlay after
+	// a like.
+	MatchWait time.Duration
+	// ComposerWait is how long ActWithMessage waits for the compliment
+	// composer to open.
+	ComposerWait time.Duration
+	// LoginWait is how long Login waits for the app after submitting the
+	// code.
+	LoginWait time.Duration
+	// SettleQuiet is how long the network and the card must stay still to
+	// count as settled (see WaitSettled).
+	SettleQuiet time.Duration
+
+	now func() time.Time
+
+	mu             sync.Mutex
+	encounters     map[string]Encounter
+	encounterOrder []string
 }
 
 func NewAdapterFromDefaults() *Adapter {
 	return &Adapter{
-		startTime:    time.Now(),
-		profileIndex: 1,
 		S:            DefaultSelectors(),
+		EntryURL:     "https://bumble.com/app",
+		MatchWait:    2 * time.Second,
+		ComposerWait: 1500 * time.Millisecond,
+		LoginWait:    30 * time.Second,
+		SettleQuiet:  300 * time.Millisecond,
+		now:          time.Now,
+		encounters:   map[string]Encounter{},
 	}
 }
 
 func (a *Adapter) Name() string { return "bumble" }
 
-func (a *Adapter) DefaultEntryURL() string { return "https://bumble.com/app" }
-
-func (a *Adapter) WaitReady(ctx context.Context, d engine.IDriver) error {
-	return d.WaitAnyVisible(ctx, a.S.ReadyHints)
-}
-
-func (a *Adapter) GetProfileId(ctx context.Context) string {
-	timestamp := a.startTime.UTC().Format(time.RFC3339)
-	return fmt.Sprintf(BumbleProfileFormat, timestamp, a.profileIndex)
-}
+func (a *Adapter) DefaultEntryURL() string { return a.EntryURL }
 
 func (a *Adapter) NextMedia(ctx context.Context, d engine.IDriver) error {
 	disabled, err := d.IsVisible(ctx, a.S.NextImageDisabled)
@@ -50,7 +63,6 @@ func (a *Adapter) NextMedia(ctx context.Context, d engine.IDriver) error {
 }
 
 func (a *Adapter) Act(ctx context.Context, d engine.IDriver, action domain.AppAction) error {
-	a.profileIndex++
 	switch action.Kind {
 	case domain.AppActionPass:
 		return d.ClickBySelectors(ctx, a.S.Pass)
@@ -58,6 +70,11 @@ func (a *Adapter) Act(ctx context.Context, d engine.IDriver, action domain.AppAc
 		return d.ClickBySelectors(ctx, a.S.Like)
 	case domain.AppActionSuperSwipe:
 		return d.ClickBySelectors(ctx, a.S.SuperSwipe)
+	case domain.AppActionUndo:
+		if err := d.ClickBySelectors(ctx, a.S.Backtrack); err != nil {
+			return fmt.Errorf("backtrack: %w", err)
+		}
+		return nil
 	default:
 		// ignore unknown actions; or return error if you prefer
 		return nil
@@ -71,3 +88,53 @@ func (a *Adapter) ScreenshotMedia(
 ) error {
 	return d.ScreenshotElement(ctx, a.S.AlbumNav, filePath)
 }
+
+// domConfidence is reported for DOM reads: the text is exact, not inferred.
+const domConfidence = 100
+
+// ReadBehaviour reads the Q&A, tag pills and bio of the current card straight
+// from the DOM. Sections missing from the card are left nil.
+func (a *Adapter) ReadBehaviour(ctx context.Context, d engine.IDriver) (*domain.BehaviourTraits, error) {
+	out := &domain.BehaviourTraits{GlobalConfidence: domConfidence}
+
+	qa, err := d.Records(ctx, a.S.QASection, map[string]string{"q": a.S.QAQuestion, "a": a.S.QAAnswer})
+	if err != nil {
+		return nil, fmt.Errorf("read q&a: %w", err)
+	}
+	for _, rec := range qa {
+		if rec["q"] == "" || rec["a"] == "" {
+			continue
+		}
+		if out.QASections == nil {
+			out.QASections = &domain.QASectionsBlock{Confidence: domConfidence, QA: map[string][]string{}}
+		}
+		out.QASections.QA[rec["q"]] = append(out.QASections.QA[rec["q"]], rec["a"])
+	}
+
+	tags, err := d.Records(ctx, a.S.Tag, map[string]string{"key": "@" + a.S.TagKeyAttr, "value": a.S.TagValue})
+	if err != nil {
+		return nil, fmt.Errorf("read tags: %w", err)
+	}
+	for _, rec := range tags {
+		if rec["value"] == "" {
+			continue
+		}
+		if out.ProfileTags == nil {
+			out.ProfileTags = &domain.ProfileTagsBlock{Confidence: domConfidence, Tags: map[string][]string{}}
+		}
+		if rec["key"] == "" {
+			out.ProfileTags.Raw = append(out.ProfileTags.Raw, rec["value"])
+			continue
+		}
+		out.ProfileTags.Tags[rec["key"]] = append(out.ProfileTags.Tags[rec["key"]], rec["value"])
+	}
+
+	lines, err := d.Texts(ctx, a.S.AboutText)
+	if err != nil {
+		return nil, fmt.Errorf("read about: %w", err)
+	}
+	if len(lines) > 0 {
+		out.RawText = &domain.RawTextBlock{Confidence: domConfidence, Lines: lines}
+	}
+	return out, nil
+}
@@ -0,0 +1,165 @@
+package bumble
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/apps/internal/apptest"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func newTestDriver(t *testing.T, fb *fakebrowser.Browser) engine.IDriver {
+	return apptest.NewDriver(t, fb, "https://bumble.test/app")
+}
+
+func TestAdapterNextMediaClicksNext(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Show(a.S.NextImage[0])
+	d := newTestDriver(t, fb)
+
+	if err := a.NextMedia(context.Background(), d); err != nil {
+		t.Fatalf("NextMedia returned error: %v", err)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.NextImage[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestAdapterNextMediaStopsWhenDisabled(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Show(a.S.NextImage[0], a.S.NextImageDisabled[0])
+	d := newTestDriver(t, fb)
+
+	if err := a.NextMedia(context.Background(), d); err == nil {
+		t.Fatalf("expected error when next navigation is disabled")
+	}
+	if got := fb.Clicks(); len(got) != 0 {
+		t.Fatalf("expected no clicks, got %v", got)
+	}
+}
+
+func TestAdapterActClicksMatchingControl(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	cases := []struct {
+		kind domain.AppActionType
+		want string
+	}{
+		{domain.AppActionPass, a.S.Pass[0]},
+		{domain.AppActionLike, a.S.Like[0]},
+		{domain.AppActionSuperSwipe, a.S.SuperSwipe[0]},
+		{domain.AppActionUndo, a.S.Backtrack[0]},
+	}
+
+	for _, tc := range cases {
+		fb := fakebrowser.New()
+		fb.Show(a.S.Pass[0], a.S.Like[0], a.S.SuperSwipe[0], a.S.Backtrack[0])
+		d := newTestDriver(t, fb)
+
+		if err := a.Act(context.Background(), d, domain.AppAction{Kind: tc.kind}); err != nil {
+			t.Fatalf("Act(%s) returned error: %v", tc.kind, err)
+		}
+		if got := fb.Clicks(); !reflect.DeepEqual(got, []string{tc.want}) {
+			t.Fatalf("Act(%s) clicked %v, want %s", tc.kind, got, tc.want)
+		}
+	}
+}
+
+func TestAdapterActFallsBackToSecondarySelector(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Show(a.S.Like[1])
+	d := newTestDriver(t, fb)
+
+	if err := a.Act(context.Background(), d, domain.AppAction{Kind: domain.AppActionLike}); err != nil {
+		t.Fatalf("Act returned error: %v", err)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.Like[1]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestAdapterActIgnoresUnknownKind(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	d := newTestDriver(t, fb)
+
+	if err := a.Act(context.Background(), d, domain.AppAction{Kind: "WAVE"}); err != nil {
+		t.Fatalf("Act returned error for unknown kind: %v", err)
+	}
+	if got := fb.Clicks(); len(got) != 0 {
+		t.Fatalf("expected no clicks, got %v", got)
+	}
+}
+
+func TestAdapterReadBehaviourFromDOM(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.SetAll(a.S.QASection,
+		fakebrowser.Node{Children: map[string][]fakebrowser.Node{
+			a.S.QAQuestion: {{Text: "My ideal Sunday"}},
+			a.S.QAAnswer:   {{Text: "Farmers market, then a nap"}},
+		}},
+		fakebrowser.Node{Children: map[string][]fakebrowser.Node{
+			a.S.QAQuestion: {{Text: "Unanswered"}},
+		}},
+	)
+	fb.SetAll(a.S.Tag,
+		fakebrowser.Node{
+			Attrs:    map[string]string{a.S.TagKeyAttr: "exercise"},
+			Children: map[string][]fakebrowser.Node{a.S.TagValue: {{Text: "Active"}}},
+		},
+		fakebrowser.Node{Children: map[string][]fakebrowser.Node{a.S.TagValue: {{Text: "Gemini"}}}},
+	)
+	fb.SetAll(a.S.AboutText, fakebrowser.Node{Text: "Looking for my hiking buddy."})
+	d := newTestDriver(t, fb)
+
+	got, err := a.ReadBehaviour(context.Background(), d)
+	if err != nil {
+		t.Fatalf("ReadBehaviour returned error: %v", err)
+	}
+	want := &domain.BehaviourTraits{
+		GlobalConfidence: 100,
+		RawText:          &domain.RawTextBlock{Confidence: 100, Lines: []string{"Looking for my hiking buddy."}},
+		QASections:       &domain.QASectionsBlock{Confidence: 100, QA: map[string][]string{"My ideal Sunday": {"Farmers market, then a nap"}}},
+		ProfileTags: &domain.ProfileTagsBlock{
+			Confidence: 100,
+			Tags:       map[string][]string{"exercise": {"Active"}},
+			Raw:        []string{"Gemini"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadBehaviour = %#v, want %#v", got, want)
+	}
+}
+
+func TestAdapterReadBehaviourEmptyCard(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	d := newTestDriver(t, fakebrowser.New())
+
+	got, err := a.ReadBehaviour(context.Background(), d)
+	if err != nil {
+		t.Fatalf("ReadBehaviour returned error: %v", err)
+	}
+	if got.QASections != nil || got.ProfileTags != nil || got.RawText != nil {
+		t.Fatalf("expected no sections, got %#v", got)
+	}
+}
@@ -0,0 +1,31 @@
+package bumble
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+)
+
+// AlbumLength counts the photos of the card on screen from the album's
+// progress dots. Cards without dots fall back to the photo list of the
+// matching collected encounter; 0 means the length is unknown.
+func (a *Adapter) AlbumLength(ctx context.Context, d engine.IDriver) (int, error) {
+	dots, err := d.Records(ctx, a.S.AlbumDots, nil)
+	if err != nil {
+		return 0, fmt.Errorf("read album dots: %w", err)
+	}
+	if len(dots) > 0 {
+		return len(dots), nil
+	}
+
+	key, err := a.GetProfileId(ctx, d)
+	if err != nil {
+		return 0, nil
+	}
+	if enc, ok := a.Encounter(strings.TrimPrefix(key, ProfileKeyPrefix)); ok {
+		return len(enc.PhotoURLs), nil
+	}
+	return 0, nil
+}
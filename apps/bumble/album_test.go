@@ -0,0 +1,62 @@
+package bumble
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func TestAlbumLengthCountsDots(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	showCard(fb, a, "Maya", ", 29", "https://cdn.test/1.jpg")
+	fb.SetAll(a.S.AlbumDots, fakebrowser.Node{Visible: true}, fakebrowser.Node{Visible: true}, fakebrowser.Node{Visible: true})
+	d := newTestDriver(t, fb)
+
+	n, err := a.AlbumLength(context.Background(), d)
+	if err != nil {
+		t.Fatalf("AlbumLength returned error: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("AlbumLength = %d, want 3", n)
+	}
+}
+
+func TestAlbumLengthFallsBackToEncounter(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	if err := a.addEncounters(engine.NetworkResponse{URL: encountersURL, Body: readFixture(t, "encounters.json")}); err != nil {
+		t.Fatalf("addEncounters returned error: %v", err)
+	}
+	fb := fakebrowser.New()
+	showCard(fb, a, "Maya", ", 29", "https://cdn.test/1.jpg")
+	d := newTestDriver(t, fb)
+
+	n, err := a.AlbumLength(context.Background(), d)
+	if err != nil {
+		t.Fatalf("AlbumLength returned error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("AlbumLength = %d, want 2", n)
+	}
+}
+
+func TestAlbumLengthUnknown(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	d := newTestDriver(t, fakebrowser.New())
+
+	n, err := a.AlbumLength(context.Background(), d)
+	if err != nil {
+		t.Fatalf("AlbumLength returned error: %v", err)
+	}
+	if n != 0 {
+		t.Fatalf("AlbumLength = %d, want 0", n)
+	}
+}
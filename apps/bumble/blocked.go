@@ -0,0 +1,87 @@
+package bumble
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/internal/cardkit"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// DetectBlock checks for the out-of-likes view, a paywall modal and the
+// empty deck, in that order. The reset time is read from BlockTimer when the
+// view shows a countdown.
+func (a *Adapter) DetectBlock(ctx context.Context, d engine.IDriver) (*domain.BlockedError, error) {
+	for _, c := range []struct {
+		kind domain.BlockKind
+		sels []string
+	}{
+		{domain.BlockOutOfLikes, a.S.OutOfLikes},
+		{domain.BlockPaywall, a.S.Paywall},
+		{domain.BlockDeckEmpty, a.S.DeckEmpty},
+	} {
+		visible, err := d.IsVisible(ctx, c.sels)
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			continue
+		}
+
+		blk := &domain.BlockedError{Kind: c.kind}
+		if blk.Detail, err = cardkit.FirstText(ctx, d, a.S.BlockDetail); err != nil {
+			return nil, err
+		}
+		timer, err := cardkit.FirstText(ctx, d, a.S.BlockTimer)
+		if err != nil {
+			return nil, err
+		}
+		if wait, ok := parseCountdown(timer); ok {
+			blk.ResetAt = a.now().Add(wait)
+		}
+		return blk, nil
+	}
+	return nil, nil
+}
+
+var (
+	clockRe = regexp.MustCompile(`(\d+):(\d{2})(?::(\d{2}))?`)
+	unitRe  = regexp.MustCompile(`(\d+)\s*(days?|d|hours?|hrs?|h|minutes?|mins?|m|seconds?|secs?|s)\b`)
+)
+
+// parseCountdown reads a countdown as shown by the app: a clock
+// ("11:59:30", or "04:30" as minutes and seconds) or units ("3h 20m",
+// "in 45 minutes").
+func parseCountdown(s string) (time.Duration, bool) {
+	s = strings.ToLower(s)
+	if m := clockRe.FindStringSubmatch(s); m != nil {
+		a, _ := strconv.Atoi(m[1])
+		b, _ := strconv.Atoi(m[2])
+		if m[3] == "" {
+			return time.Duration(a)*time.Minute + time.Duration(b)*time.Second, true
+		}
+		c, _ := strconv.Atoi(m[3])
+		return time.Duration(a)*time.Hour + time.Duration(b)*time.Minute + time.Duration(c)*time.Second, true
+	}
+
+	var total time.Duration
+	matches := unitRe.FindAllStringSubmatch(s, -1)
+	for _, m := range matches {
+		n, _ := strconv.Atoi(m[1])
+		unit := time.Second
+		switch m[2][0] {
+		case 'd':
+			unit = 24 * time.Hour
+		case 'h':
+			unit = time.Hour
+		case 'm':
+			unit = time.Minute
+		}
+		total += time.Duration(n) * unit
+	}
+	return total, len(matches) > 0
+}
@@ -0,0 +1,80 @@
+package bumble
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func TestDetectBlockOutOfLikesWithReset(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	a := NewAdapterFromDefaults()
+	a.now = func() time.Time { return now }
+	fb := fakebrowser.New()
+	fb.Show(a.S.OutOfLikes[0])
+	fb.Set(a.S.BlockDetail, fakebrowser.Node{Visible: true, Text: "You've run out of likes"})
+	fb.Set(a.S.BlockTimer, fakebrowser.Node{Visible: true, Text: "11:59:30"})
+	d := newTestDriver(t, fb)
+
+	blk, err := a.DetectBlock(context.Background(), d)
+	if err != nil {
+		t.Fatalf("DetectBlock returned error: %v", err)
+	}
+	if blk == nil || blk.Kind != domain.BlockOutOfLikes {
+		t.Fatalf("DetectBlock = %#v, want out of likes", blk)
+	}
+	if want := now.Add(11*time.Hour + 59*time.Minute + 30*time.Second); !blk.ResetAt.Equal(want) {
+		t.Fatalf("ResetAt = %s, want %s", blk.ResetAt, want)
+	}
+	if blk.Detail != "You've run out of likes" {
+		t.Fatalf("Detail = %q", blk.Detail)
+	}
+}
+
+func TestDetectBlockEmptyDeckAndNone(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	d := newTestDriver(t, fb)
+
+	blk, err := a.DetectBlock(context.Background(), d)
+	if err != nil || blk != nil {
+		t.Fatalf("DetectBlock = %#v, %v; want nil, nil", blk, err)
+	}
+
+	fb.Show(a.S.DeckEmpty[1])
+	blk, err = a.DetectBlock(context.Background(), d)
+	if err != nil {
+		t.Fatalf("DetectBlock returned error: %v", err)
+	}
+	if blk == nil || blk.Kind != domain.BlockDeckEmpty || !blk.ResetAt.IsZero() {
+		t.Fatalf("DetectBlock = %#v, want empty deck without reset", blk)
+	}
+}
+
+func TestParseCountdown(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]time.Duration{
+		"11:59:30":            11*time.Hour + 59*time.Minute + 30*time.Second,
+		"04:30":               4*time.Minute + 30*time.Second,
+		"Come back in 3h 20m": 3*time.Hour + 20*time.Minute,
+		"in 45 minutes":       45 * time.Minute,
+		"New likes in 1 day":  24 * time.Hour,
+		"2 hours 5 seconds":   2*time.Hour + 5*time.Second,
+	} {
+		got, ok := parseCountdown(in)
+		if !ok || got != want {
+			t.Fatalf("parseCountdown(%q) = %s, %v; want %s", in, got, ok, want)
+		}
+	}
+	if _, ok := parseCountdown("Upgrade to Premium"); ok {
+		t.Fatalf("expected no countdown in plain text")
+	}
+}
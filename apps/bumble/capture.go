@@ -0,0 +1,42 @@
+package bumble
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/imaging"
+)
+
+// ScreenshotProfile scrolls through every section of the current card (album,
+// name, bio, Q&A, tags, ...), screenshots each one and stitches them top to
+// bottom into filePath. Section crops are written next to it as
+// "<name>_section_NN.png"; they are returned when keepSections is set and
+// removed otherwise.
+func (a *Adapter) ScreenshotProfile(ctx context.Context, d engine.IDriver, filePath string, keepSections bool) ([]string, error) {
+	ext := filepath.Ext(filePath)
+	base := strings.TrimSuffix(filePath, ext)
+	sections, err := d.ScreenshotSections(ctx, a.S.CardSections, func(i int) string {
+		return fmt.Sprintf("%s_section_%02d.png", base, i)
+	})
+	if !keepSections {
+		defer func() {
+			for _, p := range sections {
+				_ = os.Remove(p)
+			}
+		}()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("capture card sections: %w", err)
+	}
+	if err := imaging.StitchVertical(filePath, sections...); err != nil {
+		return nil, fmt.Errorf("stitch card sections: %w", err)
+	}
+	if !keepSections {
+		return nil, nil
+	}
+	return sections, nil
+}
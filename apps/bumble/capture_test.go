@@ -0,0 +1,66 @@
+package bumble
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func pngBytes(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestAdapterScreenshotProfileStitchesSections(t *testing.T) {
+	t.Parallel()
+
+	for _, keep := range []bool{false, true} {
+		a := NewAdapterFromDefaults()
+		fb := fakebrowser.New()
+		fb.SetAll(a.S.CardSections,
+			fakebrowser.Node{Screenshot: pngBytes(t, 400, 560)},
+			fakebrowser.Node{Screenshot: pngBytes(t, 400, 80)},
+			fakebrowser.Node{Screenshot: pngBytes(t, 380, 120)},
+		)
+		d := newTestDriver(t, fb)
+
+		out := filepath.Join(t.TempDir(), "p1_card.png")
+		sections, err := a.ScreenshotProfile(context.Background(), d, out, keep)
+		if err != nil {
+			t.Fatalf("ScreenshotProfile(keep=%v) returned error: %v", keep, err)
+		}
+
+		f, err := os.Open(out)
+		if err != nil {
+			t.Fatalf("open stitched image: %v", err)
+		}
+		cfg, err := png.DecodeConfig(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("decode stitched image: %v", err)
+		}
+		if cfg.Width != 400 || cfg.Height != 760 {
+			t.Fatalf("stitched size = %dx%d, want 400x760", cfg.Width, cfg.Height)
+		}
+
+		crop := filepath.Join(filepath.Dir(out), "p1_card_section_02.png")
+		_, statErr := os.Stat(crop)
+		if keep {
+			if len(sections) != 3 || sections[1] != crop || statErr != nil {
+				t.Fatalf("expected kept crops, got %v (stat: %v)", sections, statErr)
+			}
+		} else if sections != nil || !os.IsNotExist(statErr) {
+			t.Fatalf("expected crops removed, got %v (stat: %v)", sections, statErr)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package bumble
+
+import (
+	"context"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/internal/cardkit"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// ReadProfileCard reads the name, age, location and badges of the card on
+// screen. Only a missing name is an error; other facts stay zero when absent.
+func (a *Adapter) ReadProfileCard(ctx context.Context, d engine.IDriver) (*domain.ProfileCard, error) {
+	return cardkit.Read(ctx, d, cardkit.Fields{
+		Name:           a.S.CardName,
+		Age:            a.S.CardAge,
+		Distance:       a.S.CardDistance,
+		Location:       a.S.CardLocation,
+		Job:            a.S.CardJob,
+		Education:      a.S.CardEducation,
+		Verified:       a.S.CardVerified,
+		RecentlyActive: a.S.CardRecentlyActive,
+		Premium:        a.S.CardPremium,
+	}, ErrProfileUnidentified)
+}
@@ -0,0 +1,54 @@
+package bumble
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func TestAdapterReadProfileCard(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	showCard(fb, a, "Maya", ", 29", "https://cdn.test/1.jpg")
+	fb.Set(a.S.CardDistance, fakebrowser.Node{Visible: true, Text: "3 km away"})
+	fb.Set(a.S.CardLocation, fakebrowser.Node{Visible: true, Text: "Hackney, London"})
+	fb.Set(a.S.CardJob, fakebrowser.Node{Visible: true, Text: "Architect"})
+	fb.Set(a.S.CardVerified, fakebrowser.Node{Visible: true})
+	fb.Set(a.S.CardRecentlyActive, fakebrowser.Node{Visible: true, Text: "Recently active"})
+	d := newTestDriver(t, fb)
+
+	got, err := a.ReadProfileCard(context.Background(), d)
+	if err != nil {
+		t.Fatalf("ReadProfileCard returned error: %v", err)
+	}
+	want := &domain.ProfileCard{
+		Name:           "Maya",
+		Age:            29,
+		Distance:       "3 km away",
+		Location:       "Hackney, London",
+		Job:            "Architect",
+		Verified:       true,
+		RecentlyActive: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadProfileCard = %#v, want %#v", got, want)
+	}
+}
+
+func TestAdapterReadProfileCardNeedsName(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	d := newTestDriver(t, fakebrowser.New())
+
+	_, err := a.ReadProfileCard(context.Background(), d)
+	if !errors.Is(err, ErrProfileUnidentified) || !errors.Is(err, domain.ErrProfileUnidentified) {
+		t.Fatalf("expected ErrProfileUnidentified, got %v", err)
+	}
+}
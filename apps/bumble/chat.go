@@ -0,0 +1,96 @@
+package bumble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/internal/cardkit"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// ErrEmptyMessage is returned by SendMessage for blank text.
+var ErrEmptyMessage = errors.New("bumble: empty message")
+
+// ListConversations reads the contact list in the sidebar, top to bottom.
+// Contacts without an id are skipped.
+func (a *Adapter) ListConversations(ctx context.Context, d engine.IDriver) ([]domain.Conversation, error) {
+	recs, err := d.Records(ctx, a.S.Contact, map[string]string{
+		"id":      "@" + a.S.ContactIDAttr,
+		"name":    a.S.ContactName,
+		"preview": a.S.ContactPreview,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read contacts: %w", err)
+	}
+	out := make([]domain.Conversation, 0, len(recs))
+	for _, rec := range recs {
+		if rec["id"] == "" {
+			continue
+		}
+		out = append(out, domain.Conversation{ID: rec["id"], Name: rec["name"], Preview: rec["preview"]})
+	}
+	return out, nil
+}
+
+// OpenConversation clicks the contact with this id and waits for the chat.
+func (a *Adapter) OpenConversation(ctx context.Context, d engine.IDriver, id string) error {
+	if id == "" || strings.ContainsAny(id, `'"\`) {
+		return fmt.Errorf("bumble: invalid conversation id %q", id)
+	}
+	sel := fmt.Sprintf("%s[%s='%s']", a.S.Contact, a.S.ContactIDAttr, id)
+	if err := d.ClickBySelectors(ctx, []string{sel}); err != nil {
+		return fmt.Errorf("open conversation %s: %w", id, err)
+	}
+	return d.WaitAnyVisible(ctx, a.S.ChatReady)
+}
+
+// ReadConversation reads the open chat: its id from the active contact, the
+// name from the header and every message bubble, oldest first.
+func (a *Adapter) ReadConversation(ctx context.Context, d engine.IDriver) (*domain.Conversation, error) {
+	id, _, err := d.Attribute(ctx, a.S.ActiveContact, a.S.ContactIDAttr)
+	if err != nil {
+		return nil, fmt.Errorf("read conversation id: %w", err)
+	}
+	name, err := cardkit.FirstText(ctx, d, a.S.ChatName)
+	if err != nil {
+		return nil, fmt.Errorf("read chat name: %w", err)
+	}
+	recs, err := d.Records(ctx, a.S.Message, map[string]string{
+		"text": a.S.MessageText,
+		"time": a.S.MessageTime,
+		"dir":  "@" + a.S.MessageOutAttr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read messages: %w", err)
+	}
+
+	c := &domain.Conversation{ID: id, Name: name}
+	for _, rec := range recs {
+		if rec["text"] == "" {
+			continue
+		}
+		c.Messages = append(c.Messages, domain.Message{
+			FromMe: rec["dir"] == "out",
+			Text:   rec["text"],
+			SentAt: rec["time"],
+		})
+	}
+	return c, nil
+}
+
+// SendMessage types text into the open chat's composer and sends it.
+func (a *Adapter) SendMessage(ctx context.Context, d engine.IDriver, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return ErrEmptyMessage
+	}
+	if err := d.TypeText(ctx, a.S.Composer, text); err != nil {
+		return fmt.Errorf("type message: %w", err)
+	}
+	if err := d.ClickBySelectors(ctx, a.S.Send); err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+	return nil
+}
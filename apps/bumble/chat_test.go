@@ -0,0 +1,116 @@
+package bumble
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func TestListConversations(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.SetAll(a.S.Contact,
+		fakebrowser.Node{Visible: true, Attrs: map[string]string{"data-qa-uid": "c1"}, Children: map[string][]fakebrowser.Node{
+			a.S.ContactName:    {{Text: "Maya"}},
+			a.S.ContactPreview: {{Text: "See you Sunday!"}},
+		}},
+		fakebrowser.Node{Visible: true, Children: map[string][]fakebrowser.Node{a.S.ContactName: {{Text: "No id"}}}},
+		fakebrowser.Node{Visible: true, Attrs: map[string]string{"data-qa-uid": "c2"}, Children: map[string][]fakebrowser.Node{
+			a.S.ContactName: {{Text: "Priya"}},
+		}},
+	)
+	d := newTestDriver(t, fb)
+
+	got, err := a.ListConversations(context.Background(), d)
+	if err != nil {
+		t.Fatalf("ListConversations returned error: %v", err)
+	}
+	want := []domain.Conversation{
+		{ID: "c1", Name: "Maya", Preview: "See you Sunday!"},
+		{ID: "c2", Name: "Priya"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ListConversations = %#v, want %#v", got, want)
+	}
+}
+
+func TestOpenConversationClicksContact(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	sel := "div.contact[data-qa-role='contact'][data-qa-uid='c2']"
+	fb.Set(sel, fakebrowser.Node{Visible: true, OnClick: func(b *fakebrowser.Browser) { b.Show(a.S.ChatReady[0]) }})
+	d := newTestDriver(t, fb)
+
+	if err := a.OpenConversation(context.Background(), d, "c2"); err != nil {
+		t.Fatalf("OpenConversation returned error: %v", err)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{sel}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+	if err := a.OpenConversation(context.Background(), d, "c2'] , a[x='"); err == nil {
+		t.Fatalf("expected error for an id that breaks the selector")
+	}
+}
+
+func TestReadConversation(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Set(a.S.ActiveContact, fakebrowser.Node{Visible: true, Attrs: map[string]string{"data-qa-uid": "c1"}})
+	fb.Set(a.S.ChatName, fakebrowser.Node{Visible: true, Text: "Maya"})
+	msg := func(dir, text, at string) fakebrowser.Node {
+		return fakebrowser.Node{Visible: true, Attrs: map[string]string{"data-qa-direction": dir}, Children: map[string][]fakebrowser.Node{
+			a.S.MessageText: {{Text: text}},
+			a.S.MessageTime: {{Text: at}},
+		}}
+	}
+	fb.SetAll(a.S.Message,
+		msg("in", "Hey! Loved your trek photos", "10:02"),
+		msg("out", "Thanks! Which one?", "10:05"),
+		msg("in", "", ""), // e.g. a GIF bubble
+	)
+	d := newTestDriver(t, fb)
+
+	got, err := a.ReadConversation(context.Background(), d)
+	if err != nil {
+		t.Fatalf("ReadConversation returned error: %v", err)
+	}
+	want := &domain.Conversation{ID: "c1", Name: "Maya", Messages: []domain.Message{
+		{Text: "Hey! Loved your trek photos", SentAt: "10:02"},
+		{FromMe: true, Text: "Thanks! Which one?", SentAt: "10:05"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadConversation = %#v, want %#v", got, want)
+	}
+}
+
+func TestSendMessageTypesAndSends(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Show(a.S.Composer[0], a.S.Send[0])
+	d := newTestDriver(t, fb)
+
+	if err := a.SendMessage(context.Background(), d, "Sunday works!"); err != nil {
+		t.Fatalf("SendMessage returned error: %v", err)
+	}
+	if got := fb.Typed(a.S.Composer[0]); got != "Sunday works!" {
+		t.Fatalf("typed %q", got)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.Composer[0], a.S.Send[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+	if err := a.SendMessage(context.Background(), d, "  "); !errors.Is(err, ErrEmptyMessage) {
+		t.Fatalf("expected ErrEmptyMessage, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package bumble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// ActWithMessage sends action.Message as a compliment. A like goes through
+// the card's compliment button, whose Send also likes the profile; a
+// SuperSwipe is clicked first and the note is written if the composer opens.
+// When the card has no compliment button or the composer never shows up, the
+// plain action stands and sent is false. A note that fails after the
+// SuperSwipe went through wraps domain.ErrMessageNotSent.
+func (a *Adapter) ActWithMessage(ctx context.Context, d engine.IDriver, action domain.AppAction) (sent bool, err error) {
+	if action.Message == "" {
+		return false, a.Act(ctx, d, action)
+	}
+
+	switch action.Kind {
+	case domain.AppActionLike:
+		offered, err := d.IsVisible(ctx, a.S.ComplimentOpen)
+		if err != nil {
+			return false, err
+		}
+		if offered {
+			if err := d.ClickBySelectors(ctx, a.S.ComplimentOpen); err != nil {
+				return false, fmt.Errorf("open compliment: %w", err)
+			}
+			sent, err := a.writeCompliment(ctx, d, action.Message)
+			if sent || err != nil {
+				return sent, err
+			}
+		}
+		return false, d.ClickBySelectors(ctx, a.S.Like)
+	case domain.AppActionSuperSwipe:
+		if err := d.ClickBySelectors(ctx, a.S.SuperSwipe); err != nil {
+			return false, err
+		}
+		sent, err := a.writeCompliment(ctx, d, action.Message)
+		if err != nil {
+			return false, fmt.Errorf("%w: %w", domain.ErrMessageNotSent, err)
+		}
+		return sent, nil
+	default:
+		return false, a.Act(ctx, d, action)
+	}
+}
+
+// writeCompliment waits up to ComposerWait for the compliment composer, then
+// types msg and sends it. It reports false, nil when no composer shows up.
+func (a *Adapter) writeCompliment(ctx context.Context, d engine.IDriver, msg string) (bool, error) {
+	wctx, cancel := context.WithTimeout(ctx, a.ComposerWait)
+	err := d.WaitAnyVisible(wctx, a.S.ComplimentInput)
+	cancel()
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, engine.ErrElementNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("wait for compliment composer: %w", err)
+	}
+
+	if err := d.TypeText(ctx, a.S.ComplimentInput, msg); err != nil {
+		return false, fmt.Errorf("type compliment: %w", err)
+	}
+	if err := d.ClickBySelectors(ctx, a.S.ComplimentSend); err != nil {
+		return false, fmt.Errorf("send compliment: %w", err)
+	}
+	return true, nil
+}
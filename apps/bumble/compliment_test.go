@@ -0,0 +1,96 @@
+package bumble
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func TestActWithMessageSendsCompliment(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Set(a.S.ComplimentOpen[0], fakebrowser.Node{Visible: true, OnClick: func(b *fakebrowser.Browser) {
+		b.Show(a.S.ComplimentInput[0], a.S.ComplimentSend[0])
+	}})
+	fb.Show(a.S.Like[0])
+	d := newTestDriver(t, fb)
+
+	sent, err := a.ActWithMessage(context.Background(), d, domain.AppAction{Kind: domain.AppActionLike, Message: "Which trail was that?"})
+	if err != nil {
+		t.Fatalf("ActWithMessage returned error: %v", err)
+	}
+	if !sent {
+		t.Fatalf("expected the compliment to be sent")
+	}
+	if got := fb.Typed(a.S.ComplimentInput[0]); got != "Which trail was that?" {
+		t.Fatalf("typed %q", got)
+	}
+	want := []string{a.S.ComplimentOpen[0], a.S.ComplimentInput[0], a.S.ComplimentSend[0]}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestActWithMessageFallsBackToLike(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Show(a.S.Like[0])
+	d := newTestDriver(t, fb)
+
+	sent, err := a.ActWithMessage(context.Background(), d, domain.AppAction{Kind: domain.AppActionLike, Message: "hi"})
+	if err != nil {
+		t.Fatalf("ActWithMessage returned error: %v", err)
+	}
+	if sent {
+		t.Fatalf("expected no compliment without the button")
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.Like[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestActWithMessageSuperSwipeWithoutComposer(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	a.ComposerWait = 50 * time.Millisecond
+	fb := fakebrowser.New()
+	fb.Show(a.S.SuperSwipe[0])
+	d := newTestDriver(t, fb)
+
+	sent, err := a.ActWithMessage(context.Background(), d, domain.AppAction{Kind: domain.AppActionSuperSwipe, Message: "hi"})
+	if err != nil {
+		t.Fatalf("ActWithMessage returned error: %v", err)
+	}
+	if sent {
+		t.Fatalf("expected plain superswipe when no composer opens")
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.SuperSwipe[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestActWithMessageSuperSwipeAppliedWhenComplimentFails(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Set(a.S.SuperSwipe[0], fakebrowser.Node{Visible: true, OnClick: func(b *fakebrowser.Browser) {
+		b.Show(a.S.ComplimentInput[0]) // no Send button
+	}})
+	d := newTestDriver(t, fb)
+
+	sent, err := a.ActWithMessage(context.Background(), d, domain.AppAction{Kind: domain.AppActionSuperSwipe, Message: "hi"})
+	if sent || !errors.Is(err, domain.ErrMessageNotSent) {
+		t.Fatalf("ActWithMessage = %v, %v; want the superswipe applied and ErrMessageNotSent", sent, err)
+	}
+}
@@ -0,0 +1,124 @@
+package bumble
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+)
+
+// Encounter is one profile from the encounters API, as served to the web app.
+type Encounter struct {
+	UserID    string
+	Name      string
+	Age       int
+	PhotoURLs []string
+	// Fields maps profile field ids ("aboutme_text", "location", ...) to
+	// their display values.
+	Fields map[string]string
+}
+
+// encountersPayload is the subset of the SERVER_GET_ENCOUNTERS response we read.
+type encountersPayload struct {
+	Body []struct {
+		ClientEncounters *struct {
+			Results []struct {
+				User encounterUser `json:"user"`
+			} `json:"results"`
+		} `json:"client_encounters"`
+	} `json:"body"`
+}
+
+type encounterUser struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	Age    int    `json:"age"`
+	Albums []struct {
+		Photos []struct {
+			LargeURL string `json:"large_url"`
+		} `json:"photos"`
+	} `json:"albums"`
+	ProfileFields []struct {
+		ID           string `json:"id"`
+		DisplayValue string `json:"display_value"`
+	} `json:"profile_fields"`
+}
+
+// CollectResponses watches the encounters API so every profile the web app
+// loads is kept (see Encounter). Call it before the page is opened.
+func (a *Adapter) CollectResponses(ctx context.Context, d engine.IDriver) (func(), error) {
+	return d.WatchResponses(ctx, a.S.EncountersAPI, func(r engine.NetworkResponse) {
+		if err := a.addEncounters(r); err != nil {
+			log.Printf("bumble: %v", err)
+		}
+	})
+}
+
+func (a *Adapter) addEncounters(r engine.NetworkResponse) error {
+	var p encountersPayload
+	if err := r.DecodeJSON(&p); err != nil {
+		return fmt.Errorf("decode encounters payload from %s: %w", r.URL, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, b := range p.Body {
+		if b.ClientEncounters == nil {
+			continue
+		}
+		for _, res := range b.ClientEncounters.Results {
+			enc := res.User.encounter()
+			if enc.UserID == "" {
+				continue
+			}
+			if _, seen := a.encounters[enc.UserID]; !seen {
+				a.encounterOrder = append(a.encounterOrder, enc.UserID)
+			}
+			a.encounters[enc.UserID] = enc
+		}
+	}
+	return nil
+}
+
+func (u encounterUser) encounter() Encounter {
+	enc := Encounter{UserID: u.UserID, Name: u.Name, Age: u.Age, Fields: map[string]string{}}
+	for _, album := range u.Albums {
+		for _, ph := range album.Photos {
+			if ph.LargeURL == "" {
+				continue
+			}
+			url := ph.LargeURL
+			if strings.HasPrefix(url, "//") {
+				url = "https:" + url
+			}
+			enc.PhotoURLs = append(enc.PhotoURLs, url)
+		}
+	}
+	for _, f := range u.ProfileFields {
+		if f.ID != "" && f.DisplayValue != "" {
+			enc.Fields[f.ID] = f.DisplayValue
+		}
+	}
+	return enc
+}
+
+// Encounters returns the collected profiles in the order they first arrived.
+func (a *Adapter) Encounters() []Encounter {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]Encounter, 0, len(a.encounterOrder))
+	for _, id := range a.encounterOrder {
+		out = append(out, a.encounters[id])
+	}
+	return out
+}
+
+// Encounter looks up a collected profile by user id.
+func (a *Adapter) Encounter(userID string) (Encounter, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	enc, ok := a.encounters[userID]
+	return enc, ok
+}
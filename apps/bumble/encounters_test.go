@@ -0,0 +1,94 @@
+package bumble
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+const encountersURL = "https://eu1.bumble.com/mwebapi.phtml?SERVER_GET_ENCOUNTERS"
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	buf, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	return buf
+}
+
+func TestAdapterCollectsEncountersPayload(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	d := newTestDriver(t, fb)
+
+	stop, err := a.CollectResponses(context.Background(), d)
+	if err != nil {
+		t.Fatalf("CollectResponses returned error: %v", err)
+	}
+	defer stop()
+
+	fb.RespondJSON(encountersURL, readFixture(t, "encounters.json"))
+	fb.RespondJSON("https://eu1.bumble.com/mwebapi.phtml?SERVER_ENCOUNTERS_VOTE", []byte(`{"body":[]}`))
+
+	got := a.Encounters()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 encounters, got %d: %#v", len(got), got)
+	}
+	want := Encounter{
+		UserID: "zAhMACjE3NDU0NzE3OQgg",
+		Name:   "Maya",
+		Age:    29,
+		PhotoURLs: []string{
+			"https://pd2eu.bumbcdn.com/p33/1094/large.jpg",
+			"https://pd2eu.bumbcdn.com/p33/1095/large.jpg",
+		},
+		Fields: map[string]string{
+			"aboutme_text":     "Sunday markets and long walks.",
+			"location":         "Hackney, London",
+			"lifestyle_height": "168 cm",
+		},
+	}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("first encounter = %#v, want %#v", got[0], want)
+	}
+	if got[1].UserID != "zAgEACjE3MzkyMDI0NTgg" || got[1].PhotoURLs[0] != "https://pd2eu.bumbcdn.com/p51/2001/large.jpg" {
+		t.Fatalf("unexpected second encounter: %#v", got[1])
+	}
+	if enc, ok := a.Encounter("zAgEACjE3MzkyMDI0NTgg"); !ok || enc.Name != "Priya" {
+		t.Fatalf("Encounter lookup = %#v, %v", enc, ok)
+	}
+}
+
+func TestAdapterEncountersKeepsFirstSeenOrder(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fixture := engine.NetworkResponse{URL: encountersURL, Body: readFixture(t, "encounters.json")}
+	for _, r := range []engine.NetworkResponse{fixture, {URL: encountersURL, Body: readFixture(t, "encounters_empty.json")}, fixture} {
+		if err := a.addEncounters(r); err != nil {
+			t.Fatalf("addEncounters returned error: %v", err)
+		}
+	}
+	if got := a.Encounters(); len(got) != 2 || got[0].Name != "Maya" || got[1].Name != "Priya" {
+		t.Fatalf("unexpected encounters: %#v", got)
+	}
+}
+
+func TestAdapterEncountersRejectsMalformedPayload(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	if err := a.addEncounters(engine.NetworkResponse{URL: encountersURL, Body: []byte("<html>")}); err == nil {
+		t.Fatalf("expected decode error")
+	}
+	if got := a.Encounters(); len(got) != 0 {
+		t.Fatalf("expected no encounters, got %#v", got)
+	}
+}
@@ -0,0 +1,83 @@
+package bumble
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/internal/cardkit"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// ProfileKeyPrefix starts every Bumble profile key.
+const ProfileKeyPrefix = "bumble_"
+
+// ErrProfileUnidentified means the current card has neither an id attribute
+// nor a readable name to hash.
+var ErrProfileUnidentified = fmt.Errorf("bumble: %w", domain.ErrProfileUnidentified)
+
+// GetProfileId derives a stable key for the card on screen, in order of
+// preference:
+//
+//  1. a user id data attribute on the card,
+//  2. the user id of the collected encounter with the same name and age,
+//  3. a hash of name, age and the album photo URL (without query string).
+//
+// Call it before paging the album: the hash uses the photo currently shown.
+func (a *Adapter) GetProfileId(ctx context.Context, d engine.IDriver) (string, error) {
+	for _, attr := range a.S.CardIDAttrs {
+		id, ok, err := d.Attribute(ctx, a.S.Card, attr)
+		if err != nil {
+			return "", fmt.Errorf("read %s: %w", attr, err)
+		}
+		if id = strings.TrimSpace(id); ok && id != "" {
+			return profileKey(id), nil
+		}
+	}
+
+	name, err := cardkit.FirstText(ctx, d, a.S.CardName)
+	if err != nil {
+		return "", err
+	}
+	ageText, err := cardkit.FirstText(ctx, d, a.S.CardAge)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", ErrProfileUnidentified
+	}
+	age := cardkit.Digits(ageText)
+
+	if id := a.encounterID(name, age); id != "" {
+		return profileKey(id), nil
+	}
+
+	photo, _, err := d.Attribute(ctx, a.S.CardPhoto, "src")
+	if err != nil {
+		return "", fmt.Errorf("read photo src: %w", err)
+	}
+	return cardkit.HashKey(ProfileKeyPrefix, name, age, cardkit.StripQuery(photo)), nil
+}
+
+func profileKey(id string) string { return cardkit.Key(ProfileKeyPrefix, id) }
+
+// encounterID returns the user id of the only collected encounter with this
+// name and age; ambiguous matches return "". An age missing on either side
+// (age "" or Encounter.Age 0) is unknown and matches any.
+func (a *Adapter) encounterID(name, age string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var id string
+	for _, enc := range a.encounters {
+		if enc.Name != name || (age != "" && enc.Age != 0 && strconv.Itoa(enc.Age) != age) {
+			continue
+		}
+		if id != "" {
+			return ""
+		}
+		id = enc.UserID
+	}
+	return id
+}
@@ -0,0 +1,114 @@
+package bumble
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func showCard(fb *fakebrowser.Browser, a *Adapter, name, age, photo string) {
+	fb.Set(a.S.Card, fakebrowser.Node{Visible: true})
+	fb.Set(a.S.CardName, fakebrowser.Node{Visible: true, Text: name})
+	fb.Set(a.S.CardAge, fakebrowser.Node{Visible: true, Text: age})
+	fb.Set(a.S.CardPhoto, fakebrowser.Node{Visible: true, Attrs: map[string]string{"src": photo}})
+}
+
+func TestGetProfileIdPrefersCardAttribute(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	showCard(fb, a, "Maya", ", 29", "https://cdn.test/1.jpg")
+	fb.Set(a.S.Card, fakebrowser.Node{Visible: true, Attrs: map[string]string{"data-qa-user-id": "zAh/MAC 1"}})
+	d := newTestDriver(t, fb)
+
+	got, err := a.GetProfileId(context.Background(), d)
+	if err != nil {
+		t.Fatalf("GetProfileId returned error: %v", err)
+	}
+	if got != "bumble_zAh-MAC-1" {
+		t.Fatalf("GetProfileId = %q", got)
+	}
+}
+
+func TestGetProfileIdMatchesCollectedEncounter(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	if err := a.addEncounters(engine.NetworkResponse{URL: encountersURL, Body: readFixture(t, "encounters.json")}); err != nil {
+		t.Fatalf("addEncounters returned error: %v", err)
+	}
+	fb := fakebrowser.New()
+	showCard(fb, a, "Maya", ", 29", "https://cdn.test/1.jpg")
+	d := newTestDriver(t, fb)
+
+	got, err := a.GetProfileId(context.Background(), d)
+	if err != nil {
+		t.Fatalf("GetProfileId returned error: %v", err)
+	}
+	if got != "bumble_zAhMACjE3NDU0NzE3OQgg" {
+		t.Fatalf("GetProfileId = %q", got)
+	}
+}
+
+func TestGetProfileIdMatchesEncounterWithoutAge(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	if err := a.addEncounters(engine.NetworkResponse{URL: encountersURL, Body: readFixture(t, "encounters.json")}); err != nil {
+		t.Fatalf("addEncounters returned error: %v", err)
+	}
+	fb := fakebrowser.New()
+	showCard(fb, a, "Maya", "", "https://cdn.test/1.jpg")
+	d := newTestDriver(t, fb)
+
+	got, err := a.GetProfileId(context.Background(), d)
+	if err != nil {
+		t.Fatalf("GetProfileId returned error: %v", err)
+	}
+	if got != "bumble_zAhMACjE3NDU0NzE3OQgg" {
+		t.Fatalf("GetProfileId = %q, want the encounter's id despite the missing age", got)
+	}
+}
+
+func TestGetProfileIdHashIsStable(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	key := func(name, age, photo string) string {
+		t.Helper()
+		fb := fakebrowser.New()
+		showCard(fb, a, name, age, photo)
+		got, err := a.GetProfileId(context.Background(), newTestDriver(t, fb))
+		if err != nil {
+			t.Fatalf("GetProfileId returned error: %v", err)
+		}
+		return got
+	}
+
+	first := key("Maya", ", 29", "https://cdn.test/p/1.jpg?sig=a")
+	if !strings.HasPrefix(first, ProfileKeyPrefix+"h") {
+		t.Fatalf("expected hashed key, got %q", first)
+	}
+	if again := key("Maya", "29", "https://cdn.test/p/1.jpg?sig=b"); again != first {
+		t.Fatalf("key changed across loads: %q vs %q", first, again)
+	}
+	if other := key("Maya", ", 29", "https://cdn.test/p/2.jpg"); other == first {
+		t.Fatalf("different photo produced the same key %q", other)
+	}
+}
+
+func TestGetProfileIdUnidentified(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	d := newTestDriver(t, fakebrowser.New())
+
+	if _, err := a.GetProfileId(context.Background(), d); !errors.Is(err, ErrProfileUnidentified) {
+		t.Fatalf("expected ErrProfileUnidentified, got %v", err)
+	}
+}
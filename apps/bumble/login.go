@@ -0,0 +1,125 @@
+package bumble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/internal/cardkit"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// LoggedIn reports whether the app shows one of its own screens: anything
+// recognised other than the login form or a loader.
+func (a *Adapter) LoggedIn(ctx context.Context, d engine.IDriver) (bool, error) {
+	state, err := a.CurrentState(ctx, d)
+	if err != nil {
+		return false, err
+	}
+	switch state {
+	case domain.UIStateLoggedOut, domain.UIStateLoading, domain.UIStateUnknown:
+		return false, nil
+	}
+	return true, nil
+}
+
+// Login signs in from the login form: the phone number (or email when no
+// phone is set) is entered, then the code from otp. It waits up to LoginWait
+// for the app to leave the login screen and reports the form's error message
+// when the number or code is rejected.
+func (a *Adapter) Login(ctx context.Context, d engine.IDriver, creds domain.Credentials, otp domain.OTPFunc) error {
+	field, account := a.S.LoginPhone, creds.Phone
+	if account == "" {
+		field, account = a.S.LoginEmail, creds.Email
+	}
+	if account == "" {
+		return errors.New("bumble: login: no phone or email")
+	}
+
+	if creds.Phone != "" {
+		offered, err := d.IsVisible(ctx, a.S.LoginPhoneStart)
+		if err != nil {
+			return err
+		}
+		if offered {
+			if err := d.ClickBySelectors(ctx, a.S.LoginPhoneStart); err != nil {
+				return fmt.Errorf("bumble: login: choose phone: %w", err)
+			}
+		}
+	}
+	if err := d.TypeSecret(ctx, field, account); err != nil {
+		return fmt.Errorf("bumble: login: enter account: %w", err)
+	}
+	if err := d.ClickBySelectors(ctx, a.S.LoginContinue); err != nil {
+		return fmt.Errorf("bumble: login: continue: %w", err)
+	}
+	if err := d.WaitAnyVisible(ctx, append([]string{a.S.LoginError}, a.S.LoginCode...)); err != nil {
+		return fmt.Errorf("bumble: login: wait for code prompt: %w", err)
+	}
+	if err := a.loginRejected(ctx, d); err != nil {
+		return err
+	}
+
+	code, err := otp(ctx)
+	if err != nil {
+		return fmt.Errorf("bumble: login: %w", err)
+	}
+	if err := d.TypeSecret(ctx, a.S.LoginCode, code); err != nil {
+		return fmt.Errorf("bumble: login: enter code: %w", err)
+	}
+	if err := d.ClickBySelectors(ctx, a.S.LoginCodeSubmit); err != nil {
+		return fmt.Errorf("bumble: login: submit code: %w", err)
+	}
+
+	if err := a.waitLoginResult(ctx, d); err != nil {
+		return err
+	}
+	if err := a.loginRejected(ctx, d); err != nil {
+		return err
+	}
+	return nil
+}
+
+// waitLoginResult waits up to LoginWait for an app screen or the login error.
+func (a *Adapter) waitLoginResult(ctx context.Context, d engine.IDriver) error {
+	sels := []string{a.S.LoginError}
+	for _, c := range a.stateChecks() {
+		if c.state != domain.UIStateLoggedOut && c.state != domain.UIStateLoading {
+			sels = append(sels, c.sels...)
+		}
+	}
+	sels = append(sels, a.S.ReadyHints...)
+
+	wctx, cancel := context.WithTimeout(ctx, a.LoginWait)
+	defer cancel()
+	for {
+		err := d.WaitAnyVisible(wctx, sels)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if wctx.Err() != nil || !errors.Is(err, engine.ErrElementNotFound) {
+			return fmt.Errorf("bumble: login: wait for the app: %w", err)
+		}
+	}
+}
+
+// loginRejected returns the login form's error message as an error, if one
+// is shown.
+func (a *Adapter) loginRejected(ctx context.Context, d engine.IDriver) error {
+	shown, err := d.IsVisible(ctx, []string{a.S.LoginError})
+	if err != nil || !shown {
+		return err
+	}
+	msg, err := cardkit.FirstText(ctx, d, a.S.LoginError)
+	if err != nil {
+		return err
+	}
+	if msg == "" {
+		msg = "no reason shown"
+	}
+	return fmt.Errorf("bumble: login rejected: %s", msg)
+}
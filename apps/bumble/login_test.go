@@ -0,0 +1,106 @@
+package bumble
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// loginPage scripts the login form: Continue reveals the code step and
+// submitting the code runs onSubmit.
+func loginPage(a *Adapter, onSubmit func(b *fakebrowser.Browser)) *fakebrowser.Browser {
+	fb := fakebrowser.New()
+	fb.Show(a.S.LoggedOut[0], a.S.LoginPhoneStart[0], a.S.LoginPhone[0])
+	fb.Set(a.S.LoginContinue[0], fakebrowser.Node{Visible: true, OnClick: func(b *fakebrowser.Browser) {
+		b.Show(a.S.LoginCode[0], a.S.LoginCodeSubmit[0])
+	}})
+	fb.Set(a.S.LoginError, fakebrowser.Node{})
+	fb.Set(a.S.LoginCodeSubmit[0], fakebrowser.Node{OnClick: onSubmit})
+	return fb
+}
+
+func TestLoginWithPhone(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	a.LoginWait = time.Second
+	fb := loginPage(a, func(b *fakebrowser.Browser) {
+		b.Remove(a.S.LoggedOut[0])
+		b.Show(a.S.Card, a.S.ReadyHints[0])
+	})
+	d := newTestDriver(t, fb)
+
+	var asked int
+	otp := func(context.Context) (string, error) { asked++; return "123456", nil }
+	if err := a.Login(context.Background(), d, domain.Credentials{Phone: "+447700900123", Email: "me@example.com"}, otp); err != nil {
+		t.Fatalf("Login returned error: %v", err)
+	}
+
+	if asked != 1 {
+		t.Fatalf("otp asked %d times, want 1", asked)
+	}
+	if got := fb.Typed(a.S.LoginPhone[0]); got != "+447700900123" {
+		t.Fatalf("typed phone %q", got)
+	}
+	if got := fb.Typed(a.S.LoginCode[0]); got != "123456" {
+		t.Fatalf("typed code %q", got)
+	}
+	want := []string{a.S.LoginPhoneStart[0], a.S.LoginPhone[0], a.S.LoginContinue[0], a.S.LoginCode[0], a.S.LoginCodeSubmit[0]}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("clicks = %v, want %v", got, want)
+	}
+	if ok, err := a.LoggedIn(context.Background(), d); err != nil || !ok {
+		t.Fatalf("LoggedIn = %v, %v; want true", ok, err)
+	}
+}
+
+func TestLoginFallsBackToEmail(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	a.LoginWait = time.Second
+	fb := loginPage(a, func(b *fakebrowser.Browser) {
+		b.Remove(a.S.LoggedOut[0])
+		b.Show(a.S.Card)
+	})
+	fb.Show(a.S.LoginEmail[0])
+	d := newTestDriver(t, fb)
+
+	otp := func(context.Context) (string, error) { return "9999", nil }
+	if err := a.Login(context.Background(), d, domain.Credentials{Email: "me@example.com"}, otp); err != nil {
+		t.Fatalf("Login returned error: %v", err)
+	}
+	if got := fb.Typed(a.S.LoginEmail[0]); got != "me@example.com" {
+		t.Fatalf("typed email %q", got)
+	}
+	for _, c := range fb.Clicks() {
+		if c == a.S.LoginPhoneStart[0] {
+			t.Fatalf("phone route chosen for an email login")
+		}
+	}
+}
+
+func TestLoginReportsRejectedCode(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	a.LoginWait = time.Second
+	fb := loginPage(a, func(b *fakebrowser.Browser) {
+		b.Set(a.S.LoginError, fakebrowser.Node{Visible: true, Text: "That code didn't work"})
+	})
+	d := newTestDriver(t, fb)
+
+	otp := func(context.Context) (string, error) { return "000000", nil }
+	err := a.Login(context.Background(), d, domain.Credentials{Phone: "+447700900123"}, otp)
+	if err == nil || !strings.Contains(err.Error(), "That code didn't work") {
+		t.Fatalf("expected the rejection message, got %v", err)
+	}
+	if ok, _ := a.LoggedIn(context.Background(), d); ok {
+		t.Fatalf("LoggedIn reported true on the login form")
+	}
+}
@@ -0,0 +1,48 @@
+package bumble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/internal/cardkit"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// HandleMatch watches for the match overlay for up to MatchWait after a like.
+// With no overlay it returns nil. Otherwise it reads the match's name and
+// either continues swiping (MatchDismiss) or opens the chat (MatchChat).
+func (a *Adapter) HandleMatch(ctx context.Context, d engine.IDriver, mode domain.MatchMode) (*domain.Match, error) {
+	wctx, cancel := context.WithTimeout(ctx, a.MatchWait)
+	err := d.WaitAnyVisible(wctx, a.S.MatchOverlay)
+	cancel()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, engine.ErrElementNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("wait for match overlay: %w", err)
+	}
+
+	name, err := cardkit.FirstText(ctx, d, a.S.MatchName)
+	if err != nil {
+		return nil, fmt.Errorf("read match name: %w", err)
+	}
+	m := &domain.Match{Name: name, Mode: mode}
+
+	switch mode {
+	case domain.MatchDismiss:
+		err = d.ClickBySelectors(ctx, a.S.MatchContinue)
+	case domain.MatchChat:
+		err = d.ClickBySelectors(ctx, a.S.MatchChat)
+	default:
+		return m, fmt.Errorf("unknown match mode %q", mode)
+	}
+	if err != nil {
+		return m, fmt.Errorf("%s match overlay: %w", mode, err)
+	}
+	return m, nil
+}
@@ -0,0 +1,76 @@
+package bumble
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func showMatch(fb *fakebrowser.Browser, a *Adapter, name string) {
+	fb.Show(a.S.MatchOverlay[0], a.S.MatchContinue[0], a.S.MatchChat[0])
+	fb.Set(a.S.MatchName, fakebrowser.Node{Visible: true, Text: name})
+}
+
+func TestHandleMatchDismissesOverlay(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	showMatch(fb, a, "Maya")
+	d := newTestDriver(t, fb)
+
+	m, err := a.HandleMatch(context.Background(), d, domain.MatchDismiss)
+	if err != nil {
+		t.Fatalf("HandleMatch returned error: %v", err)
+	}
+	if want := (&domain.Match{Name: "Maya", Mode: domain.MatchDismiss}); !reflect.DeepEqual(m, want) {
+		t.Fatalf("HandleMatch = %#v, want %#v", m, want)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.MatchContinue[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestHandleMatchOpensChat(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	showMatch(fb, a, "Maya")
+	d := newTestDriver(t, fb)
+
+	m, err := a.HandleMatch(context.Background(), d, domain.MatchChat)
+	if err != nil {
+		t.Fatalf("HandleMatch returned error: %v", err)
+	}
+	if m == nil || m.Mode != domain.MatchChat {
+		t.Fatalf("HandleMatch = %#v", m)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.MatchChat[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestHandleMatchWithoutOverlay(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	a.MatchWait = 50 * time.Millisecond
+	fb := fakebrowser.New()
+	d := newTestDriver(t, fb)
+
+	m, err := a.HandleMatch(context.Background(), d, domain.MatchDismiss)
+	if err != nil {
+		t.Fatalf("HandleMatch returned error: %v", err)
+	}
+	if m != nil {
+		t.Fatalf("expected no match, got %#v", m)
+	}
+	if got := fb.Clicks(); len(got) != 0 {
+		t.Fatalf("expected no clicks, got %v", got)
+	}
+}
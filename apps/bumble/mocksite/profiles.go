@@ -0,0 +1,122 @@
+package mocksite
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Profile is one synthetic card in the mock encounters deck.
+type Profile struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Age    int    `json:"age"`
+	Photos int    `json:"photos"` // number of generated album photos (min 1)
+	About  string `json:"about,omitempty"`
+	QA     []QA   `json:"qa,omitempty"`
+	Tags   []Tag  `json:"tags,omitempty"`
+
+	// Card facts and badges.
+	Job            string `json:"job,omitempty"`
+	Education      string `json:"education,omitempty"`
+	Location       string `json:"location,omitempty"`
+	Distance       string `json:"distance,omitempty"`
+	Verified       bool   `json:"verified,omitempty"`
+	RecentlyActive bool   `json:"recently_active,omitempty"`
+	Premium        bool   `json:"premium,omitempty"`
+
+	// LikesBack makes a Like or SuperSwipe on this profile a match.
+	LikesBack bool `json:"likes_back,omitempty"`
+}
+
+type QA struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// LoadProfiles reads a JSON array of profiles.
+func LoadProfiles(path string) ([]Profile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var out []Profile
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("parse profiles %s: %w", path, err)
+	}
+	return normalizeProfiles(out)
+}
+
+// DefaultProfiles generates n deterministic profiles that cycle through a few
+// shapes: with/without Q&A, one or several photos, with/without tags and
+// badges.
+func DefaultProfiles(n int) []Profile {
+	names := []string{"Asha", "Meera", "Riya", "Kavya", "Ishita", "Nisha"}
+	questions := []QA{
+		{Question: "A perfect weekend", Answer: "Trekking and a long brunch"},
+		{Question: "I'm looking for", Answer: "Someone to try new cafes with"},
+		{Question: "My love language", Answer: "Quality time"},
+	}
+	tags := []Tag{
+		{Key: "height", Value: "165 cm"},
+		{Key: "exercise", Value: "Active"},
+		{Key: "education", Value: "Graduate degree"},
+		{Key: "drinking", Value: "Socially"},
+	}
+
+	cities := []string{"Bandra, Mumbai", "Indiranagar, Bengaluru", "Hauz Khas, Delhi"}
+
+	out := make([]Profile, 0, n)
+	for i := 0; i < n; i++ {
+		p := Profile{
+			ID:     fmt.Sprintf("mock-%03d", i+1),
+			Name:   names[i%len(names)],
+			Age:    24 + i%9,
+			Photos: 1 + i%4,
+			About:  fmt.Sprintf("Synthetic profile %d for offline runs.", i+1),
+		}
+		// every third profile has fewer than two questions
+		if i%3 != 2 {
+			p.QA = questions[:2+i%2]
+		} else {
+			p.QA = questions[:1]
+		}
+		if i%2 == 0 {
+			p.Tags = tags
+			p.Job = "Product designer"
+		} else {
+			p.Tags = tags[:2]
+			p.Education = "IIT Bombay"
+		}
+		p.Location = cities[i%len(cities)]
+		p.Distance = fmt.Sprintf("%d km away", 1+i%7)
+		p.Verified = i%3 == 0
+		p.RecentlyActive = i%4 != 3
+		p.Premium = i%5 == 4
+		p.LikesBack = i%4 == 1
+		out = append(out, p)
+	}
+	return out
+}
+
+func normalizeProfiles(in []Profile) ([]Profile, error) {
+	seen := make(map[string]struct{}, len(in))
+	for i := range in {
+		if in[i].ID == "" {
+			in[i].ID = fmt.Sprintf("mock-%03d", i+1)
+		}
+		if _, ok := seen[in[i].ID]; ok {
+			return nil, fmt.Errorf("duplicate profile id %q", in[i].ID)
+		}
+		seen[in[i].ID] = struct{}{}
+		if in[i].Photos < 1 {
+			in[i].Photos = 1
+		}
+	}
+	return in, nil
+}
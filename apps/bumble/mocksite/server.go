@@ -0,0 +1,386 @@
+// Package mocksite serves a fake Bumble "encounters" deck whose DOM matches
+// bumble.DefaultSelectors, so the adapter and the decision pipeline can run
+// end to end against headless Chromium without a real account or network.
+package mocksite
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+//go:embed page.html
+var pageHTML []byte
+
+//go:embed login.html
+var loginHTML []byte
+
+// SessionCookie is the cookie the mock sets after a successful login.
+const SessionCookie = "mock_session"
+
+// Action is one recorded Like/Pass/SuperSwipe click.
+type Action struct {
+	ProfileID string               `json:"profile_id"`
+	Kind      domain.AppActionType `json:"kind"`
+	At        time.Time            `json:"at"`
+	Matched   bool                 `json:"matched,omitempty"`
+	Message   string               `json:"message,omitempty"` // compliment sent with a like
+}
+
+// Deck is what the page fetches to render the current card.
+type Deck struct {
+	Current   *Profile `json:"current"`
+	Position  int      `json:"position"` // 1-based index of Current
+	Total     int      `json:"total"`
+	Remaining int      `json:"remaining"`
+	// Match is the profile just liked when it liked back; the page shows the
+	// match overlay before the next card.
+	Match *Profile `json:"match,omitempty"`
+	// OutOfLikesUntil is set while the like quota is used up; the page shows
+	// the out-of-votes view with a countdown to it.
+	OutOfLikesUntil *time.Time `json:"out_of_likes_until,omitempty"`
+	// Interstitial is the title of a dismissable dialog shown over the deck.
+	Interstitial string `json:"interstitial,omitempty"`
+}
+
+// Server holds the deck cursor; it is safe for concurrent use.
+type Server struct {
+	mu       sync.Mutex
+	profiles []Profile
+	cursor   int
+	actions  []Action
+
+	likeQuota    int // 0 = unlimited
+	quotaResetIn time.Duration
+	likesUsed    int
+	quotaResetAt time.Time
+
+	interstitial string
+
+	loginPhone string // "" = no login required
+	loginCode  string
+	sessions   map[string]bool
+}
+
+func NewServer(profiles []Profile) (*Server, error) {
+	ps, err := normalizeProfiles(append([]Profile(nil), profiles...))
+	if err != nil {
+		return nil, err
+	}
+	return &Server{profiles: ps, sessions: map[string]bool{}}, nil
+}
+
+// RequireLogin puts /app behind a sign-in form that accepts phone and then
+// code ("" phone turns it off). Any sessions already handed out end.
+func (s *Server) RequireLogin(phone, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loginPhone, s.loginCode = phone, code
+	clear(s.sessions)
+}
+
+// ExpireSessions logs every browser out, as when the app's cookie expires.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.sessions)
+}
+
+func (s *Server) loggedIn(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loginPhone == "" {
+		return true
+	}
+	c, err := r.Cookie(SessionCookie)
+	return err == nil && s.sessions[c.Value]
+}
+
+// SetLikeQuota limits Likes and SuperSwipes to n (0 = unlimited). Once they
+// are used up, further ones are refused until resetIn has passed.
+func (s *Server) SetLikeQuota(n int, resetIn time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.likeQuota, s.quotaResetIn = n, resetIn
+	s.likesUsed, s.quotaResetAt = 0, time.Time{}
+}
+
+// SetInterstitial shows a dismissable dialog titled title over the deck
+// until POST /api/dismiss ("" clears it).
+func (s *Server) SetInterstitial(title string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interstitial = title
+}
+
+// outOfLikesLocked reports whether the quota is used up, starting a fresh
+// quota once the reset time has passed.
+func (s *Server) outOfLikesLocked(now time.Time) bool {
+	if s.likeQuota == 0 {
+		return false
+	}
+	if !s.quotaResetAt.IsZero() && !now.Before(s.quotaResetAt) {
+		s.likesUsed, s.quotaResetAt = 0, time.Time{}
+	}
+	return s.likesUsed >= s.likeQuota
+}
+
+// Handler routes:
+//
+//	GET  /app                     encounters page
+//	GET  /api/deck                current card as JSON
+//	POST /api/action              {"profile_id","kind"}; advances the deck
+//	GET  /api/actions             recorded actions
+//	POST /api/reset               rewind the deck
+//	POST /api/dismiss             close the interstitial dialog
+//	POST /api/login               {"phone"}; checks the number (RequireLogin)
+//	POST /api/login/verify        {"phone","code"}; sets the session cookie
+//	GET  /photos/{id}/{n}.svg     generated album photo
+//
+// Only the /app page is behind the login; the API stays open.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/app", http.StatusFound)
+	})
+	mux.HandleFunc("GET /app", s.handlePage)
+	mux.HandleFunc("GET /api/deck", s.handleDeck)
+	mux.HandleFunc("POST /api/action", s.handleAction)
+	mux.HandleFunc("GET /api/actions", s.handleActions)
+	mux.HandleFunc("POST /api/reset", s.handleReset)
+	mux.HandleFunc("POST /api/dismiss", s.handleDismiss)
+	mux.HandleFunc("POST /api/login", s.handleLogin)
+	mux.HandleFunc("POST /api/login/verify", s.handleLoginVerify)
+	mux.HandleFunc("GET /photos/{id}/{file}", s.handlePhoto)
+	return mux
+}
+
+// Actions returns the recorded actions, oldest first.
+func (s *Server) Actions() []Action {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Action(nil), s.actions...)
+}
+
+// Deck returns the current deck state.
+func (s *Server) Deck() Deck {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deckLocked()
+}
+
+func (s *Server) deckLocked() Deck {
+	d := Deck{Total: len(s.profiles), Remaining: len(s.profiles) - s.cursor}
+	if s.cursor < len(s.profiles) {
+		p := s.profiles[s.cursor]
+		d.Current = &p
+		d.Position = s.cursor + 1
+	}
+	if s.outOfLikesLocked(time.Now()) {
+		until := s.quotaResetAt
+		d.OutOfLikesUntil = &until
+	}
+	d.Interstitial = s.interstitial
+	return d
+}
+
+func (s *Server) handlePage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if !s.loggedIn(r) {
+		_, _ = w.Write(loginHTML)
+		return
+	}
+	_, _ = w.Write(pageHTML)
+}
+
+type loginRequest struct {
+	Phone string `json:"phone"`
+	Code  string `json:"code"`
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	known := s.loginPhone != "" && req.Phone == s.loginPhone
+	s.mu.Unlock()
+	if !known {
+		http.Error(w, "We couldn't find an account with that number", http.StatusForbidden)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleLoginVerify(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loginPhone == "" || req.Phone != s.loginPhone || req.Code != s.loginCode {
+		http.Error(w, "That code didn't work. Try again.", http.StatusForbidden)
+		return
+	}
+	token := rand.Text()
+	s.sessions[token] = true
+	http.SetCookie(w, &http.Cookie{Name: SessionCookie, Value: token, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDeck(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.Deck())
+}
+
+func (s *Server) handleAction(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ProfileID string               `json:"profile_id"`
+		Kind      domain.AppActionType `json:"kind"`
+		Message   string               `json:"message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch req.Kind {
+	case domain.AppActionPass, domain.AppActionLike, domain.AppActionSuperSwipe, domain.AppActionUndo:
+	default:
+		http.Error(w, fmt.Sprintf("unknown action %q", req.Kind), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.Kind == domain.AppActionUndo {
+		// Backtrack brings the previous card back, even from an empty deck.
+		if s.cursor == 0 {
+			http.Error(w, "nothing to undo", http.StatusConflict)
+			return
+		}
+		s.cursor--
+		s.actions = append(s.actions, Action{ProfileID: s.profiles[s.cursor].ID, Kind: req.Kind, At: time.Now()})
+		writeJSON(w, http.StatusOK, s.deckLocked())
+		return
+	}
+	if s.cursor >= len(s.profiles) {
+		http.Error(w, "deck is empty", http.StatusConflict)
+		return
+	}
+	// A stale click (card already advanced) must not skip the next profile.
+	if cur := s.profiles[s.cursor].ID; req.ProfileID != cur {
+		http.Error(w, fmt.Sprintf("profile %q is not the current card (%q)", req.ProfileID, cur), http.StatusConflict)
+		return
+	}
+	if req.Kind != domain.AppActionPass {
+		// Out of likes: the card stays and the page shows the countdown.
+		now := time.Now()
+		if s.outOfLikesLocked(now) {
+			writeJSON(w, http.StatusOK, s.deckLocked())
+			return
+		}
+		if s.likeQuota > 0 {
+			if s.likesUsed++; s.likesUsed == s.likeQuota {
+				s.quotaResetAt = now.Add(s.quotaResetIn)
+			}
+		}
+	}
+	liked := s.profiles[s.cursor]
+	matched := req.Kind != domain.AppActionPass && liked.LikesBack
+	s.actions = append(s.actions, Action{ProfileID: req.ProfileID, Kind: req.Kind, At: time.Now(), Matched: matched, Message: req.Message})
+	s.cursor++
+	d := s.deckLocked()
+	if matched {
+		d.Match = &liked
+	}
+	writeJSON(w, http.StatusOK, d)
+}
+
+func (s *Server) handleActions(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.Actions())
+}
+
+func (s *Server) handleDismiss(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	s.interstitial = ""
+	d := s.deckLocked()
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, d)
+}
+
+func (s *Server) handleReset(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	s.cursor = 0
+	s.actions = nil
+	s.likesUsed, s.quotaResetAt = 0, time.Time{}
+	d := s.deckLocked()
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, d)
+}
+
+func (s *Server) handlePhoto(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	n, err := strconv.Atoi(strings.TrimSuffix(r.PathValue("file"), ".svg"))
+	if err != nil || n < 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	var found *Profile
+	for i := range s.profiles {
+		if s.profiles[i].ID == id {
+			found = &s.profiles[i]
+			break
+		}
+	}
+	s.mu.Unlock()
+	if found == nil || n > found.Photos {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprint(w, photoSVG(found, n))
+}
+
+// photoSVG draws a flat placeholder whose colour is stable per (profile, n),
+// so consecutive album frames differ visibly in screenshots.
+func photoSVG(p *Profile, n int) string {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s/%d", p.ID, n)
+	sum := h.Sum32()
+	hue := sum % 360
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="400" height="560" viewBox="0 0 400 560">
+<rect width="400" height="560" fill="hsl(%d,55%%,55%%)"/>
+<circle cx="200" cy="210" r="90" fill="hsl(%d,40%%,85%%)"/>
+<text x="200" y="420" font-family="sans-serif" font-size="36" text-anchor="middle" fill="#fff">%s, %d</text>
+<text x="200" y="470" font-family="sans-serif" font-size="24" text-anchor="middle" fill="#fff">photo %d/%d</text>
+</svg>`, hue, (hue+180)%360, escapeXML(p.Name), p.Age, n, p.Photos)
+}
+
+func escapeXML(s string) string {
+	r := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	return r.Replace(s)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,351 @@
+package mocksite
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/bumble"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func newTestServer(t *testing.T, profiles []Profile) (*Server, *httptest.Server) {
+	t.Helper()
+	s, err := NewServer(profiles)
+	if err != nil {
+		t.Fatalf("NewServer returned error: %v", err)
+	}
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+func postAction(t *testing.T, url, profileID string, kind domain.AppActionType) (*http.Response, Deck) {
+	t.Helper()
+	body := strings.NewReader(`{"profile_id":"` + profileID + `","kind":"` + string(kind) + `"}`)
+	res, err := http.Post(url+"/api/action", "application/json", body)
+	if err != nil {
+		t.Fatalf("post action: %v", err)
+	}
+	defer res.Body.Close()
+	var d Deck
+	if res.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(res.Body).Decode(&d); err != nil {
+			t.Fatalf("decode deck: %v", err)
+		}
+	}
+	return res, d
+}
+
+func TestPageCarriesBumbleSelectorHooks(t *testing.T) {
+	t.Parallel()
+
+	_, ts := newTestServer(t, DefaultProfiles(1))
+	res, err := http.Get(ts.URL + "/app")
+	if err != nil {
+		t.Fatalf("get page: %v", err)
+	}
+	defer res.Body.Close()
+	b, _ := io.ReadAll(res.Body)
+	page := string(b)
+
+	// The DOM is built client side; check the hooks the adapter selectors rely on.
+	for _, hook := range []string{
+		`id="main"`,
+		"page__layout",
+		"page__content-inner",
+		"encounters-album__nav",
+		"encounters-album__nav-item--",
+		"encounters-album__stories-progress",
+		"encounters-match",
+		"encounters-match__name",
+		"encounters-match-continue",
+		"encounters-match-chat",
+		"encounters-compliment__input",
+		"encounters-compliment-send",
+		"encounters-out-of-votes",
+		"countdown-timer",
+		"modal-close",
+		`"role": "dialog"`,
+		`"data-qa-role": "encounters-empty"`,
+		"encounters-album__story",
+		"is-disabled",
+		"encounters-user__controls",
+		"encounters-action-",
+		"encounters-story-section--question",
+		"pill__list",
+		"encounters-story-profile__occupation",
+		"encounters-story-profile__verification",
+		"encounters-story-profile__online-status",
+		"encounters-story-profile__premium-badge",
+		"location-widget__distance",
+	} {
+		if !strings.Contains(page, hook) {
+			t.Fatalf("page is missing selector hook %q", hook)
+		}
+	}
+
+	s := bumble.DefaultSelectors()
+	if !strings.Contains(s.AlbumNav, "div.encounters-album__nav") {
+		t.Fatalf("album nav selector changed; update the mock page: %s", s.AlbumNav)
+	}
+}
+
+func TestActionsAdvanceDeck(t *testing.T) {
+	t.Parallel()
+
+	profiles := DefaultProfiles(2)
+	s, ts := newTestServer(t, profiles)
+
+	res, d := postAction(t, ts.URL, profiles[0].ID, domain.AppActionLike)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", res.StatusCode)
+	}
+	if d.Current == nil || d.Current.ID != profiles[1].ID || d.Position != 2 {
+		t.Fatalf("expected second profile, got %#v", d)
+	}
+
+	// stale click on an old card is rejected and does not advance
+	res, _ = postAction(t, ts.URL, profiles[0].ID, domain.AppActionPass)
+	if res.StatusCode != http.StatusConflict {
+		t.Fatalf("expected conflict for stale profile, got %d", res.StatusCode)
+	}
+
+	res, d = postAction(t, ts.URL, profiles[1].ID, domain.AppActionPass)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", res.StatusCode)
+	}
+	if d.Current != nil || d.Remaining != 0 {
+		t.Fatalf("expected empty deck, got %#v", d)
+	}
+
+	got := s.Actions()
+	if len(got) != 2 || got[0].Kind != domain.AppActionLike || got[1].Kind != domain.AppActionPass {
+		t.Fatalf("unexpected recorded actions: %#v", got)
+	}
+}
+
+func TestPhotoBounds(t *testing.T) {
+	t.Parallel()
+
+	profiles := []Profile{{ID: "p1", Name: "A", Age: 30, Photos: 2}}
+	_, ts := newTestServer(t, profiles)
+
+	for path, want := range map[string]int{
+		"/photos/p1/1.svg": http.StatusOK,
+		"/photos/p1/2.svg": http.StatusOK,
+		"/photos/p1/3.svg": http.StatusNotFound,
+		"/photos/p2/1.svg": http.StatusNotFound,
+	} {
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("get %s: %v", path, err)
+		}
+		res.Body.Close()
+		if res.StatusCode != want {
+			t.Fatalf("%s: status %d, want %d", path, res.StatusCode, want)
+		}
+	}
+}
+
+func TestNewServerRejectsDuplicateIDs(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewServer([]Profile{{ID: "x"}, {ID: "x"}}); err == nil {
+		t.Fatalf("expected error for duplicate profile ids")
+	}
+}
+
+func TestLikeOnLikesBackProfileIsMatch(t *testing.T) {
+	t.Parallel()
+
+	profiles := []Profile{
+		{ID: "p1", Name: "A", Age: 30, LikesBack: true},
+		{ID: "p2", Name: "B", Age: 31, LikesBack: true},
+		{ID: "p3", Name: "C", Age: 32},
+	}
+	s, ts := newTestServer(t, profiles)
+
+	_, d := postAction(t, ts.URL, "p1", domain.AppActionLike)
+	if d.Match == nil || d.Match.ID != "p1" || d.Current == nil || d.Current.ID != "p2" {
+		t.Fatalf("expected match with p1 and p2 next, got %#v", d)
+	}
+	if _, d = postAction(t, ts.URL, "p2", domain.AppActionPass); d.Match != nil {
+		t.Fatalf("pass must not match, got %#v", d.Match)
+	}
+	if _, d = postAction(t, ts.URL, "p3", domain.AppActionSuperSwipe); d.Match != nil {
+		t.Fatalf("profile does not like back, got %#v", d.Match)
+	}
+
+	got := s.Actions()
+	if !got[0].Matched || got[1].Matched || got[2].Matched {
+		t.Fatalf("unexpected matched flags: %#v", got)
+	}
+}
+
+func TestComplimentIsRecordedWithLike(t *testing.T) {
+	t.Parallel()
+
+	profiles := []Profile{{ID: "p1", Name: "A", Age: 30}}
+	s, ts := newTestServer(t, profiles)
+
+	body := strings.NewReader(`{"profile_id":"p1","kind":"LIKE","message":"Great smile!"}`)
+	res, err := http.Post(ts.URL+"/api/action", "application/json", body)
+	if err != nil {
+		t.Fatalf("post action: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", res.StatusCode)
+	}
+	if got := s.Actions(); len(got) != 1 || got[0].Message != "Great smile!" {
+		t.Fatalf("unexpected recorded actions: %#v", got)
+	}
+}
+
+func TestUndoBringsBackPreviousCard(t *testing.T) {
+	t.Parallel()
+
+	profiles := []Profile{{ID: "p1", Name: "A", Age: 30}, {ID: "p2", Name: "B", Age: 31}}
+	s, ts := newTestServer(t, profiles)
+
+	if res, _ := postAction(t, ts.URL, "", domain.AppActionUndo); res.StatusCode != http.StatusConflict {
+		t.Fatalf("expected conflict with nothing to undo, got %d", res.StatusCode)
+	}
+	postAction(t, ts.URL, "p1", domain.AppActionPass)
+	res, d := postAction(t, ts.URL, "p2", domain.AppActionUndo)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", res.StatusCode)
+	}
+	if d.Current == nil || d.Current.ID != "p1" {
+		t.Fatalf("expected p1 back on screen, got %#v", d.Current)
+	}
+	got := s.Actions()
+	if len(got) != 2 || got[1].Kind != domain.AppActionUndo || got[1].ProfileID != "p1" {
+		t.Fatalf("unexpected recorded actions: %#v", got)
+	}
+}
+
+func TestLikeQuotaBlocksUntilReset(t *testing.T) {
+	t.Parallel()
+
+	profiles := []Profile{{ID: "p1", Name: "A", Age: 30}, {ID: "p2", Name: "B", Age: 31}, {ID: "p3", Name: "C", Age: 32}}
+	s, ts := newTestServer(t, profiles)
+	s.SetLikeQuota(1, 50*time.Millisecond)
+
+	if _, d := postAction(t, ts.URL, "p1", domain.AppActionLike); d.OutOfLikesUntil == nil || d.Current.ID != "p2" {
+		t.Fatalf("expected the last free like to apply and start the countdown, got %#v", d)
+	}
+	_, d := postAction(t, ts.URL, "p2", domain.AppActionLike)
+	if d.OutOfLikesUntil == nil || d.Current.ID != "p2" {
+		t.Fatalf("expected like to be refused with the card kept, got %#v", d)
+	}
+	if _, d := postAction(t, ts.URL, "p2", domain.AppActionPass); d.Current.ID != "p3" {
+		t.Fatalf("passes must still work while out of likes, got %#v", d)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, d := postAction(t, ts.URL, "p3", domain.AppActionLike); d.Current != nil {
+		t.Fatalf("expected like to apply after the reset, got %#v", d)
+	}
+	if got := s.Actions(); len(got) != 3 {
+		t.Fatalf("refused likes must not be recorded: %#v", got)
+	}
+}
+
+func TestInterstitialUntilDismissed(t *testing.T) {
+	t.Parallel()
+
+	s, ts := newTestServer(t, DefaultProfiles(1))
+	s.SetInterstitial("Turn on notifications")
+	if got := s.Deck().Interstitial; got != "Turn on notifications" {
+		t.Fatalf("Interstitial = %q", got)
+	}
+
+	res, err := http.Post(ts.URL+"/api/dismiss", "application/json", nil)
+	if err != nil {
+		t.Fatalf("post dismiss: %v", err)
+	}
+	res.Body.Close()
+	if got := s.Deck().Interstitial; got != "" {
+		t.Fatalf("expected interstitial cleared, got %q", got)
+	}
+}
+
+func TestLoginGuardsPageUntilCodeVerified(t *testing.T) {
+	t.Parallel()
+
+	s, ts := newTestServer(t, DefaultProfiles(1))
+	s.RequireLogin("+447700900123", "123456")
+	sel := bumble.DefaultSelectors()
+
+	getPage := func(cookies ...*http.Cookie) string {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/app", nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("get page: %v", err)
+		}
+		defer res.Body.Close()
+		b, _ := io.ReadAll(res.Body)
+		return string(b)
+	}
+	post := func(path, body string) *http.Response {
+		t.Helper()
+		res, err := http.Post(ts.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("post %s: %v", path, err)
+		}
+		res.Body.Close()
+		return res
+	}
+
+	page := getPage()
+	for _, hook := range []string{`data-qa-role="login-form"`, `data-qa-role="login-with-phone"`, `type="tel"`,
+		`data-qa-role="login-continue"`, `autocomplete="one-time-code"`, `data-qa-role="login-code-submit"`, `data-qa-role="login-error"`} {
+		if !strings.Contains(page, hook) {
+			t.Fatalf("login page is missing hook %q", hook)
+		}
+	}
+	if !strings.Contains(sel.LoggedOut[0], "login-form") {
+		t.Fatalf("LoggedOut selector no longer targets the login form: %q", sel.LoggedOut[0])
+	}
+
+	if res := post("/api/login", `{"phone":"+440000000000"}`); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("unknown number: status %d", res.StatusCode)
+	}
+	if res := post("/api/login", `{"phone":"+447700900123"}`); res.StatusCode != http.StatusNoContent {
+		t.Fatalf("known number: status %d", res.StatusCode)
+	}
+	if res := post("/api/login/verify", `{"phone":"+447700900123","code":"000000"}`); res.StatusCode != http.StatusForbidden {
+		t.Fatalf("wrong code: status %d", res.StatusCode)
+	}
+	res := post("/api/login/verify", `{"phone":"+447700900123","code":"123456"}`)
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("right code: status %d", res.StatusCode)
+	}
+	var session *http.Cookie
+	for _, c := range res.Cookies() {
+		if c.Name == SessionCookie {
+			session = c
+		}
+	}
+	if session == nil {
+		t.Fatalf("no %s cookie set", SessionCookie)
+	}
+	if page := getPage(session); !strings.Contains(page, `id="deck"`) {
+		t.Fatalf("session cookie did not open the deck")
+	}
+
+	s.ExpireSessions()
+	if page := getPage(session); !strings.Contains(page, "login-form") {
+		t.Fatalf("expired session still opens the deck")
+	}
+}
@@ -1,13 +1,103 @@
 package bumble
 
+import (
+	"github.com/vd09-projects/swipeassist/apps/declarative"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
 type Selectors struct {
 	NextImage         []string
 	NextImageDisabled []string
 	Pass              []string
 	SuperSwipe        []string
 	Like              []string
+	Backtrack         []string // undoes the last Pass/Like
 	ReadyHints        []string
 	AlbumNav          string
+	AlbumDots         string // one per photo in the album's progress bar
+
+	// Current card identity.
+	Card        string
+	CardIDAttrs []string // data attributes on Card carrying the user id
+	CardName    string
+	CardAge     string
+	CardPhoto   string
+
+	// Card facts and badges.
+	CardDistance       string
+	CardLocation       string
+	CardJob            string
+	CardEducation      string
+	CardVerified       string
+	CardRecentlyActive string
+	CardPremium        string
+
+	// CardSections matches every block of the card, top to bottom, for
+	// full-card capture.
+	CardSections string
+
+	// Profile story (DOM text extraction).
+	QASection  string // one per prompt
+	QAQuestion string // within QASection
+	QAAnswer   string // within QASection
+	Tag        string // one per pill
+	TagKeyAttr string // attribute on Tag naming the tag kind
+	TagValue   string // within Tag
+	AboutText  string
+
+	// "It's a match" overlay shown after a like.
+	MatchOverlay  []string
+	MatchName     string // within MatchOverlay
+	MatchContinue []string
+	MatchChat     []string
+
+	// Other screens (see CurrentState).
+	Loading    []string
+	LoggedOut  []string
+	ErrorPage  []string
+	Modal      []string // dismissable dialogs and interstitials
+	ModalClose []string // within Modal
+
+	// Login flow (see Login). LoginPhoneStart picks the phone route on the
+	// welcome screen; both steps stay inside the LoggedOut form.
+	LoginPhoneStart []string
+	LoginPhone      []string
+	LoginEmail      []string
+	LoginContinue   []string
+	LoginCode       []string
+	LoginCodeSubmit []string
+	LoginError      string // message shown for a rejected number or code
+
+	// Blocking states (see DetectBlock), checked in this order.
+	OutOfLikes  []string
+	Paywall     []string
+	DeckEmpty   []string
+	BlockTimer  string // countdown to the reset, e.g. "11:59:30" or "3h 20m"
+	BlockDetail string // headline of the blocking view
+
+	// Compliment composer. ComplimentOpen on the card starts a like with a
+	// note; after a SuperSwipe the composer may open on its own.
+	ComplimentOpen  []string
+	ComplimentInput []string
+	ComplimentSend  []string
+
+	// Chat: the contact list and the open conversation.
+	Contact        string // one per conversation in the sidebar
+	ContactIDAttr  string // attribute on Contact carrying the conversation id
+	ContactName    string // within Contact
+	ContactPreview string // within Contact
+	ActiveContact  string // the open conversation's Contact
+	ChatReady      []string
+	ChatName       string
+	Message        string // one per bubble, oldest first
+	MessageText    string // within Message
+	MessageTime    string // within Message
+	MessageOutAttr string // attribute on Message; "out" for our own bubbles
+	Composer       []string
+	Send           []string
+
+	// EncountersAPI are URL globs of the XHRs that deliver encounter cards.
+	EncountersAPI []string
 }
 
 func DefaultSelectors() Selectors {
@@ -32,10 +122,184 @@ func DefaultSelectors() Selectors {
 			"div[data-qa-role='encounters-action-like'][role='button']",
 			"div.encounters-action.encounters-action--like[role='button']",
 		},
+		Backtrack: []string{
+			"div[data-qa-role='encounters-action-backtrack'][role='button']",
+			"div.encounters-action.encounters-action--backtrack[role='button']",
+		},
 		ReadyHints: []string{
 			"div.encounters-user__controls",
 			"article",
 		},
-		AlbumNav: "#main > div > div.page__layout > main > div.page__content-inner > div > div > span > div:nth-child(1) > article > div.encounters-album__nav",
+		AlbumNav:  "#main > div > div.page__layout > main > div.page__content-inner > div > div > span > div:nth-child(1) > article > div.encounters-album__nav",
+		AlbumDots: "div.encounters-album__stories-progress > div.encounters-album__story",
+
+		Card:        "div.encounters-user",
+		CardIDAttrs: []string{"data-qa-user-id", "data-user-id"},
+		CardName:    ".encounters-story-profile__name",
+		CardAge:     ".encounters-story-profile__age",
+		CardPhoto:   "img.encounters-album__photo",
+
+		CardDistance:       ".location-widget__distance",
+		CardLocation:       ".location-widget__town",
+		CardJob:            ".encounters-story-profile__occupation",
+		CardEducation:      ".encounters-story-profile__education",
+		CardVerified:       ".encounters-story-profile__verification",
+		CardRecentlyActive: ".encounters-story-profile__online-status",
+		CardPremium:        ".encounters-story-profile__premium-badge",
+
+		CardSections: "article.encounters-album > div.encounters-album__nav, section.encounters-story-profile, section.encounters-story-section",
+
+		QASection:  "section.encounters-story-section--question",
+		QAQuestion: ".encounters-story-section__heading",
+		QAAnswer:   ".encounters-story-section__content",
+		Tag:        "section.encounters-story-section--tags li.pill",
+		TagKeyAttr: "data-qa-type",
+		TagValue:   ".pill__title",
+		AboutText:  "section.encounters-story-section--about .encounters-story-about__text",
+
+		MatchOverlay: []string{
+			"div[data-qa-role='encounters-match']",
+			"div.encounters-match",
+		},
+		MatchName: "div.encounters-match .encounters-match__name",
+		MatchContinue: []string{
+			"div.encounters-match [data-qa-role='encounters-match-continue'][role='button']",
+			"div.encounters-match button.encounters-match__continue",
+		},
+		MatchChat: []string{
+			"div.encounters-match [data-qa-role='encounters-match-chat'][role='button']",
+			"div.encounters-match button.encounters-match__chat",
+		},
+
+		Loading: []string{
+			"[data-qa-role='loader']",
+			"div.spinner",
+		},
+		LoggedOut: []string{
+			"form[data-qa-role='login-form']",
+			"div.sign-in",
+		},
+		ErrorPage: []string{
+			"[data-qa-role='error-page']",
+			"div.error-page",
+		},
+		Modal: []string{
+			"div[role='dialog'][data-qa-role='modal']",
+			"div.modal[role='dialog']",
+		},
+		ModalClose: []string{
+			"div[role='dialog'] [data-qa-role='modal-close'][role='button']",
+			"div[role='dialog'] button[aria-label='Close']",
+		},
+
+		LoginPhoneStart: []string{
+			"[data-qa-role='login-with-phone'][role='button']",
+			"button.sign-in__phone",
+		},
+		LoginPhone: []string{
+			"input[data-qa-role='login-phone']",
+			"form[data-qa-role='login-form'] input[type='tel']",
+		},
+		LoginEmail: []string{
+			"input[data-qa-role='login-email']",
+			"form[data-qa-role='login-form'] input[type='email']",
+		},
+		LoginContinue: []string{
+			"[data-qa-role='login-continue'][role='button']",
+			"form[data-qa-role='login-form'] button.sign-in__continue",
+		},
+		LoginCode: []string{
+			"input[data-qa-role='login-code']",
+			"input[autocomplete='one-time-code']",
+		},
+		LoginCodeSubmit: []string{
+			"[data-qa-role='login-code-submit'][role='button']",
+			"form[data-qa-role='login-form'] button.sign-in__verify",
+		},
+		LoginError: "form[data-qa-role='login-form'] [data-qa-role='login-error']",
+
+		OutOfLikes: []string{
+			"div[data-qa-role='encounters-out-of-votes']",
+			"div.encounters-out-of-votes",
+		},
+		Paywall: []string{
+			"div[data-qa-role='paywall']",
+			"div.paywall-modal",
+		},
+		DeckEmpty: []string{
+			"div[data-qa-role='encounters-empty']",
+			"div.encounters-empty",
+		},
+		BlockTimer:  "[data-qa-role='countdown-timer'], .countdown-timer",
+		BlockDetail: ".encounters-out-of-votes__title, .paywall-modal__title, .encounters-empty__title",
+
+		ComplimentOpen: []string{
+			"div.encounters-user__controls [data-qa-role='encounters-action-compliment'][role='button']",
+			"div.encounters-action--compliment",
+		},
+		ComplimentInput: []string{
+			"div.encounters-compliment textarea.encounters-compliment__input",
+			"div.encounters-compliment textarea",
+		},
+		ComplimentSend: []string{
+			"div.encounters-compliment [data-qa-role='encounters-compliment-send'][role='button']",
+			"div.encounters-compliment button.encounters-compliment__send",
+		},
+
+		Contact:        "div.contact[data-qa-role='contact']",
+		ContactIDAttr:  "data-qa-uid",
+		ContactName:    ".contact__name",
+		ContactPreview: ".contact__message",
+		ActiveContact:  "div.contact.is-active[data-qa-role='contact']",
+		ChatReady: []string{
+			"div.messages-list",
+			"div.message-field",
+		},
+		ChatName:       ".messages-header__name",
+		Message:        "div.messages-list div.message",
+		MessageText:    ".message-bubble__text",
+		MessageTime:    ".message__time",
+		MessageOutAttr: "data-qa-direction",
+		Composer: []string{
+			"div.message-field textarea.textarea__input",
+			"div.message-field textarea",
+		},
+		Send: []string{
+			"div.message-field [data-qa-role='chat-send-message'][role='button']",
+			"div.message-field button.message-field__send",
+		},
+
+		EncountersAPI: []string{
+			"*/mwebapi.phtml?SERVER_GET_ENCOUNTERS*",
+		},
 	}
 }
+
+// ApplySpec takes the selectors a declarative spec for BUMBLE sets, so a
+// selector fix is a YAML change; the rest keep their defaults.
+func (a *Adapter) ApplySpec(s declarative.Spec) error {
+	declarative.Overlay(&a.EntryURL, s.EntryURL)
+	declarative.Overlay(&a.S.ReadyHints, s.ReadyHints)
+	declarative.Overlay(&a.S.NextImage, s.NextMedia.Click)
+	declarative.Overlay(&a.S.NextImageDisabled, s.NextMedia.Disabled)
+	declarative.Overlay(&a.S.Pass, s.Actions[domain.AppActionPass])
+	declarative.Overlay(&a.S.Like, s.Actions[domain.AppActionLike])
+	declarative.Overlay(&a.S.SuperSwipe, s.Actions[domain.AppActionSuperSwipe])
+	declarative.Overlay(&a.S.Backtrack, s.Actions[domain.AppActionUndo])
+	declarative.Overlay(&a.S.AlbumNav, s.MediaCapture)
+
+	c := s.Card
+	declarative.Overlay(&a.S.Card, c.Root)
+	declarative.Overlay(&a.S.CardIDAttrs, c.IDAttrs)
+	declarative.Overlay(&a.S.CardName, c.Name)
+	declarative.Overlay(&a.S.CardAge, c.Age)
+	declarative.Overlay(&a.S.CardPhoto, c.Photo)
+	declarative.Overlay(&a.S.CardDistance, c.Distance)
+	declarative.Overlay(&a.S.CardLocation, c.Location)
+	declarative.Overlay(&a.S.CardJob, c.Job)
+	declarative.Overlay(&a.S.CardEducation, c.Education)
+	declarative.Overlay(&a.S.CardVerified, c.Verified)
+	declarative.Overlay(&a.S.CardRecentlyActive, c.RecentlyActive)
+	declarative.Overlay(&a.S.CardPremium, c.Premium)
+	return nil
+}
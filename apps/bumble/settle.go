@@ -0,0 +1,27 @@
+package bumble
+
+import (
+	"context"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+)
+
+// WaitSettled returns once the card has loaded: no requests in flight, the
+// card done sliding in and rendering, and its shown photo decoded.
+func (a *Adapter) WaitSettled(ctx context.Context, d engine.IDriver) error {
+	return d.WaitFor(ctx,
+		engine.NetworkIdle(a.SettleQuiet),
+		engine.BoxStable(a.S.Card, a.SettleQuiet/2),
+		engine.DOMStable(a.S.Card, a.SettleQuiet),
+		engine.ImagesLoaded(a.S.Card),
+	)
+}
+
+// WaitMediaSettled returns once the photo NextMedia switched to is decoded
+// and the album has stopped changing.
+func (a *Adapter) WaitMediaSettled(ctx context.Context, d engine.IDriver) error {
+	return d.WaitFor(ctx,
+		engine.ImagesLoaded(a.S.Card),
+		engine.DOMStable(a.S.Card, a.SettleQuiet/2),
+	)
+}
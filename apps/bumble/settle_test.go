@@ -0,0 +1,51 @@
+package bumble
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func TestWaitSettledWaitsForPhoto(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	a.SettleQuiet = 40 * time.Millisecond
+	fb := fakebrowser.New()
+	fb.Set(a.S.Card, fakebrowser.Node{Visible: true, ImagesLoading: true})
+	d := newTestDriver(t, fb)
+	time.AfterFunc(100*time.Millisecond, func() { fb.Set(a.S.Card, fakebrowser.Node{Visible: true}) })
+
+	start := time.Now()
+	if err := a.WaitSettled(context.Background(), d); err != nil {
+		t.Fatalf("WaitSettled returned error: %v", err)
+	}
+	if took := time.Since(start); took < 100*time.Millisecond {
+		t.Fatalf("WaitSettled returned after %s, before the photo decoded", took)
+	}
+	if err := a.WaitMediaSettled(context.Background(), d); err != nil {
+		t.Fatalf("WaitMediaSettled returned error: %v", err)
+	}
+}
+
+func TestWaitSettledNeedsCard(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	a.SettleQuiet = 10 * time.Millisecond
+	fb := fakebrowser.New()
+	cfg := engine.DefaultConfig()
+	cfg.SettleTimeout = 200 * time.Millisecond
+	d := engine.NewDriver(engine.NewWithBrowser(cfg, fb))
+	if err := d.Open(context.Background(), "https://bumble.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	if err := a.WaitSettled(context.Background(), d); !errors.Is(err, engine.ErrWaitTimeout) {
+		t.Fatalf("expected ErrWaitTimeout without a card, got %v", err)
+	}
+}
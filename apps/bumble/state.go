@@ -0,0 +1,83 @@
+package bumble
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// stateChecks lists the screens in the order CurrentState tests them: pages
+// that replace the app first, then overlays, then the deck itself.
+func (a *Adapter) stateChecks() []struct {
+	state domain.UIState
+	sels  []string
+} {
+	return []struct {
+		state domain.UIState
+		sels  []string
+	}{
+		{domain.UIStateLoggedOut, a.S.LoggedOut},
+		{domain.UIStateError, a.S.ErrorPage},
+		{domain.UIStateBlocked, append(append([]string(nil), a.S.OutOfLikes...), a.S.Paywall...)},
+		{domain.UIStateModal, a.S.Modal},
+		{domain.UIStateDeckEmpty, a.S.DeckEmpty},
+		{domain.UIStateCard, []string{a.S.Card}},
+		{domain.UIStateLoading, a.S.Loading},
+	}
+}
+
+// CurrentState classifies the screen right now; UIStateUnknown when nothing
+// matches.
+func (a *Adapter) CurrentState(ctx context.Context, d engine.IDriver) (domain.UIState, error) {
+	for _, c := range a.stateChecks() {
+		visible, err := d.IsVisible(ctx, c.sels)
+		if err != nil {
+			return domain.UIStateUnknown, err
+		}
+		if visible {
+			return c.state, nil
+		}
+	}
+	return domain.UIStateUnknown, nil
+}
+
+// DismissModal closes the dialog on screen with its close button.
+func (a *Adapter) DismissModal(ctx context.Context, d engine.IDriver) error {
+	if err := d.ClickBySelectors(ctx, a.S.ModalClose); err != nil {
+		return fmt.Errorf("dismiss modal: %w", err)
+	}
+	return nil
+}
+
+// WaitReady waits until the app settles on a known screen (anything but
+// loading), then requires the deck with a card. A modal in front of the deck
+// is dismissed first; other screens are reported as not ready.
+func (a *Adapter) WaitReady(ctx context.Context, d engine.IDriver) error {
+	var settled []string
+	for _, c := range a.stateChecks() {
+		if c.state != domain.UIStateLoading {
+			settled = append(settled, c.sels...)
+		}
+	}
+	settled = append(settled, a.S.ReadyHints...)
+	if err := d.WaitAnyVisible(ctx, settled); err != nil {
+		return err
+	}
+
+	state, err := a.CurrentState(ctx, d)
+	if err != nil {
+		return err
+	}
+	if state == domain.UIStateModal {
+		if err := a.DismissModal(ctx, d); err != nil {
+			return err
+		}
+		return d.WaitAnyVisible(ctx, a.S.ReadyHints)
+	}
+	if state != domain.UIStateCard && state != domain.UIStateUnknown {
+		return fmt.Errorf("bumble: not ready: screen is %s", state)
+	}
+	return d.WaitAnyVisible(ctx, a.S.ReadyHints)
+}
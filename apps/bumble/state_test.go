@@ -0,0 +1,78 @@
+package bumble
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func TestCurrentStateClassifiesScreens(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	cases := []struct {
+		show []string
+		want domain.UIState
+	}{
+		{nil, domain.UIStateUnknown},
+		{[]string{a.S.Loading[0]}, domain.UIStateLoading},
+		{[]string{a.S.Card, a.S.Loading[1]}, domain.UIStateCard},
+		{[]string{a.S.DeckEmpty[1]}, domain.UIStateDeckEmpty},
+		{[]string{a.S.Card, a.S.Modal[0]}, domain.UIStateModal},
+		{[]string{a.S.Card, a.S.Modal[1], a.S.Paywall[0]}, domain.UIStateBlocked},
+		{[]string{a.S.OutOfLikes[1]}, domain.UIStateBlocked},
+		{[]string{a.S.ErrorPage[1]}, domain.UIStateError},
+		{[]string{a.S.LoggedOut[0], a.S.Loading[0]}, domain.UIStateLoggedOut},
+	}
+
+	for _, tc := range cases {
+		fb := fakebrowser.New()
+		fb.Show(tc.show...)
+		d := newTestDriver(t, fb)
+
+		got, err := a.CurrentState(context.Background(), d)
+		if err != nil {
+			t.Fatalf("CurrentState(%v) returned error: %v", tc.show, err)
+		}
+		if got != tc.want {
+			t.Fatalf("CurrentState(%v) = %s, want %s", tc.show, got, tc.want)
+		}
+	}
+}
+
+func TestWaitReadyDismissesModal(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Show(a.S.Card, a.S.ReadyHints[0], a.S.Modal[0])
+	fb.Set(a.S.ModalClose[0], fakebrowser.Node{Visible: true, OnClick: func(b *fakebrowser.Browser) {
+		b.Set(a.S.Modal[0], fakebrowser.Node{Visible: false})
+	}})
+	d := newTestDriver(t, fb)
+
+	if err := a.WaitReady(context.Background(), d); err != nil {
+		t.Fatalf("WaitReady returned error: %v", err)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.ModalClose[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestWaitReadyReportsLoggedOut(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Show(a.S.LoggedOut[0])
+	d := newTestDriver(t, fb)
+
+	err := a.WaitReady(context.Background(), d)
+	if err == nil || !strings.Contains(err.Error(), string(domain.UIStateLoggedOut)) {
+		t.Fatalf("expected not-ready error naming the screen, got %v", err)
+	}
+}
@@ -2,10 +2,15 @@ package apps
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"os"
 
 	"github.com/vd09-projects/swipeassist/apps/engine"
 	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/swipeassist/internal/retry"
+	"github.com/vd09-projects/swipeassist/internal/vault"
 )
 
 type Config struct {
@@ -13,64 +18,458 @@ type Config struct {
 	EntryURL   string         // optional override; else adapter.DefaultEntryURL()
 	Headless   bool
 	ControlURL string
+
+	AdapterDir string           // declarative adapter specs; DefaultAdapterDir when empty
+	OnMatch    domain.MatchMode // match overlay handling; MatchDismiss when empty
+
+	// SessionFile keeps cookies and storage across launched browsers (see
+	// engine.Config.SessionFile).
+	SessionFile string
+
+	// BrowserProfile sets the launched browser up and the device every page
+	// emulates (see engine.LoadProfile).
+	BrowserProfile engine.Profile
+
+	RetryObserver retry.Observer // optional; sees every driver retry attempt
+
+	TracePath  string // optional; record every driver call to this JSON-lines file
+	ReplayPath string // optional; serve driver calls from a recorded trace instead of a browser
+
+	// Automated login: when set, Open gets past the login screen with the
+	// session cookies and credentials kept in this encrypted vault.
+	VaultPath       string
+	VaultPassphrase string
+	OTP             domain.OTPFunc // login code source; PromptOTP on stdin when nil
 }
 
+// ErrUnsupported is returned for calls the app's adapter does not implement.
+var ErrUnsupported = errors.New("apps: not supported by adapter")
+
+// Screens that explain a failed step (see StateReader).
+var (
+	ErrLoggedOut = errors.New("apps: logged out")
+	ErrErrorPage = errors.New("apps: app shows an error page")
+)
+
+// ErrNotApplied is wrapped by the *domain.BlockedError Act returns when the
+// click went through but a block swallowed the action.
+var ErrNotApplied = errors.New("apps: action not applied")
+
 type GenericClient struct {
 	cfg     Config
 	adapter Adapter
 	driver  engine.IDriver
+	vault   *vault.Vault // nil without Config.VaultPath
+
+	stopCollect func()
 }
 
 func New(cfg Config) (*GenericClient, error) {
-	ad, ok := GetAdapterRegistry()[cfg.AppName]
+	dir := cfg.AdapterDir
+	if dir == "" {
+		dir = DefaultAdapterDir
+	}
+	// A broken spec only matters to the app it is for.
+	reg, err := LoadAdapterRegistry(dir)
+	if serr := specError(err, cfg.AppName); serr != nil {
+		return nil, fmt.Errorf("adapter spec for %s: %w", cfg.AppName, serr)
+	}
+	ad, ok := reg[cfg.AppName]
 	if !ok {
+		if err != nil {
+			return nil, fmt.Errorf("unknown app %q (%w)", cfg.AppName, err)
+		}
 		return nil, fmt.Errorf("unknown app %q", cfg.AppName)
 	}
+	if err != nil {
+		log.Printf("apps: %v", err)
+	}
 
-	ec := engine.DefaultConfig()
-	ec.Headless = cfg.Headless
-	ec.ControlURL = cfg.ControlURL
+	var v *vault.Vault
+	if cfg.VaultPath != "" {
+		if v, err = vault.Open(cfg.VaultPath, cfg.VaultPassphrase); err != nil {
+			return nil, err
+		}
+	}
 
-	eng, err := engine.New(ec)
+	drv, err := newDriver(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	drv := engine.NewDriver(eng)
-
 	return &GenericClient{
 		cfg:     cfg,
 		adapter: ad,
 		driver:  drv,
+		vault:   v,
 	}, nil
 }
 
-func (c *GenericClient) Close() { c.driver.Close() }
+func newDriver(cfg Config) (engine.IDriver, error) {
+	if cfg.ReplayPath != "" {
+		return engine.NewReplayDriver(cfg.ReplayPath)
+	}
+
+	ec := engine.DefaultConfig()
+	ec.Headless = cfg.Headless
+	ec.ControlURL = cfg.ControlURL
+	ec.SessionFile = cfg.SessionFile
+	ec.Profile = cfg.BrowserProfile
+	ec.RetryObserver = cfg.RetryObserver
+
+	eng, err := engine.New(ec)
+	if err != nil {
+		return nil, err
+	}
+
+	drv := engine.NewDriver(eng)
+	if cfg.TracePath != "" {
+		if err := drv.StartTrace(cfg.TracePath); err != nil {
+			_ = drv.Close()
+			return nil, fmt.Errorf("start trace: %w", err)
+		}
+	}
+	return drv, nil
+}
+
+func (c *GenericClient) Close() error {
+	if c.stopCollect != nil {
+		c.stopCollect()
+	}
+	return c.driver.Close()
+}
 
+// Open navigates to the entry URL and waits for the adapter to report ready.
+// A failed wait comes back as *domain.BlockedError, ErrLoggedOut or
+// ErrErrorPage when the adapter can tell why. With a vault configured, a
+// login screen is handled by logIn.
 func (c *GenericClient) Open(ctx context.Context) error {
 	url := c.cfg.EntryURL
 	if url == "" {
 		url = c.adapter.DefaultEntryURL()
 	}
+	if rc, ok := c.adapter.(ResponseCollector); ok && c.stopCollect == nil {
+		stop, err := rc.CollectResponses(ctx, c.driver)
+		if err != nil {
+			return fmt.Errorf("collect responses: %w", err)
+		}
+		c.stopCollect = stop
+	}
+	if err := c.navigate(ctx, url); err != nil {
+		return err
+	}
+	err := c.explain(ctx, c.adapter.WaitReady(ctx, c.driver))
+	if errors.Is(err, ErrLoggedOut) && c.vault != nil {
+		err = c.logIn(ctx, url, err)
+	}
+	c.driver.MarkLoggedIn(err == nil)
+	return err
+}
+
+func (c *GenericClient) navigate(ctx context.Context, url string) error {
 	if err := c.driver.Open(ctx, url); err != nil {
+		if !engine.IsRecoverable(err) {
+			return err
+		}
+		// Slow first paints and mid-load re-renders are common on these SPAs;
+		// give navigation one more go.
+		log.Printf("apps: %v; retrying open once", err)
+		return c.driver.Open(ctx, url)
+	}
+	return nil
+}
+
+// logIn gets past the login screen that made Open fail with loggedOut: the
+// vault's saved cookies are tried first, then the adapter's login flow (see
+// Loginer) with the vault's credentials. The new session cookies are saved
+// back to the vault.
+func (c *GenericClient) logIn(ctx context.Context, url string, loggedOut error) error {
+	app := c.cfg.AppName
+	if cookies := c.vault.Cookies(app); len(cookies) > 0 {
+		if err := c.driver.SetCookies(ctx, cookies); err != nil {
+			return fmt.Errorf("restore session cookies: %w", err)
+		}
+		if err := c.navigate(ctx, url); err != nil {
+			return err
+		}
+		err := c.explain(ctx, c.adapter.WaitReady(ctx, c.driver))
+		if !errors.Is(err, ErrLoggedOut) {
+			return err
+		}
+		log.Printf("apps: saved %s session was rejected; logging in", app)
+		loggedOut = err
+	}
+
+	lf, ok := c.adapter.(Loginer)
+	if !ok {
+		return loggedOut
+	}
+	creds, ok := c.vault.Credentials(app)
+	if !ok {
+		return fmt.Errorf("%w (no %s credentials in vault %s)", loggedOut, app, c.vault.Path())
+	}
+	otp := c.cfg.OTP
+	if otp == nil {
+		otp = PromptOTP(os.Stdin, os.Stderr)
+	}
+	if err := lf.Login(ctx, c.driver, creds, otp); err != nil {
+		return fmt.Errorf("%w: login: %w", ErrLoggedOut, err)
+	}
+	if ok, err := lf.LoggedIn(ctx, c.driver); err != nil {
+		return fmt.Errorf("check login: %w", err)
+	} else if !ok {
+		return fmt.Errorf("%w: still on the login screen after login", ErrLoggedOut)
+	}
+	if err := c.saveSession(ctx); err != nil {
+		log.Printf("apps: keep %s session: %v", app, err)
+	}
+	return c.explain(ctx, c.adapter.WaitReady(ctx, c.driver))
+}
+
+// saveSession stores the browser's cookies for the app in the vault.
+func (c *GenericClient) saveSession(ctx context.Context) error {
+	cookies, err := c.driver.Cookies(ctx)
+	if err != nil {
 		return err
 	}
-	return c.adapter.WaitReady(ctx, c.driver)
+	c.vault.SetCookies(c.cfg.AppName, cookies)
+	return c.vault.Save()
 }
 
-func (c *GenericClient) GetProfileId(ctx context.Context) string {
-	return c.adapter.GetProfileId(ctx)
+// Recover checks the browser after a failed step. When the connection or
+// the tab is gone it reconnects and opens the app again, so WaitReady (and a
+// vault login) run as on a fresh start. reconnected reports whether that
+// happened; err comes from the reconnect or the reopen.
+func (c *GenericClient) Recover(ctx context.Context) (reconnected bool, err error) {
+	if err := c.driver.Alive(ctx); !errors.Is(err, engine.ErrConnectionLost) {
+		return false, nil
+	}
+	log.Printf("apps: browser connection lost; reconnecting")
+	if err := c.driver.Reconnect(ctx); err != nil {
+		return true, fmt.Errorf("reconnect: %w", err)
+	}
+	return true, c.Open(ctx)
+}
+
+func (c *GenericClient) GetProfileId(ctx context.Context) (string, error) {
+	id, err := c.adapter.GetProfileId(ctx, c.driver)
+	return id, c.explain(ctx, err)
+}
+
+// Blocked reports the blocking state on screen (see BlockDetector), or nil
+// when nothing blocks or the adapter can't tell.
+func (c *GenericClient) Blocked(ctx context.Context) (*domain.BlockedError, error) {
+	bd, ok := c.adapter.(BlockDetector)
+	if !ok {
+		return nil, nil
+	}
+	return bd.DetectBlock(ctx, c.driver)
+}
+
+// CurrentState classifies the screen (see StateReader), or returns
+// ErrUnsupported.
+func (c *GenericClient) CurrentState(ctx context.Context) (domain.UIState, error) {
+	sr, ok := c.adapter.(StateReader)
+	if !ok {
+		return domain.UIStateUnknown, fmt.Errorf("%w: %s screen state", ErrUnsupported, c.adapter.Name())
+	}
+	state, err := sr.CurrentState(ctx, c.driver)
+	switch {
+	case err != nil:
+	case state == domain.UIStateCard:
+		c.driver.MarkLoggedIn(true)
+	case state == domain.UIStateLoggedOut, state == domain.UIStateError:
+		c.driver.MarkLoggedIn(false)
+	}
+	return state, err
+}
+
+// DismissModal closes the dialog on screen (see StateReader).
+func (c *GenericClient) DismissModal(ctx context.Context) error {
+	sr, ok := c.adapter.(StateReader)
+	if !ok {
+		return fmt.Errorf("%w: %s dismiss modal", ErrUnsupported, c.adapter.Name())
+	}
+	return sr.DismissModal(ctx, c.driver)
+}
+
+// explain turns err into a *domain.BlockedError when a blocking state
+// explains it, or wraps ErrLoggedOut / ErrErrorPage when the adapter sees
+// that screen; otherwise err is returned unchanged.
+func (c *GenericClient) explain(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if blk, derr := c.Blocked(ctx); derr == nil && blk != nil {
+		blk.Err = err
+		return blk
+	}
+	switch state, _ := c.CurrentState(ctx); state {
+	case domain.UIStateLoggedOut:
+		return fmt.Errorf("%w: %w", ErrLoggedOut, err)
+	case domain.UIStateError:
+		return fmt.Errorf("%w: %w", ErrErrorPage, err)
+	}
+	return err
+}
+
+// ReadProfileCard reads the current card's facts (see CardReader), or
+// returns ErrUnsupported.
+func (c *GenericClient) ReadProfileCard(ctx context.Context) (*domain.ProfileCard, error) {
+	cr, ok := c.adapter.(CardReader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s profile card", ErrUnsupported, c.adapter.Name())
+	}
+	return cr.ReadProfileCard(ctx, c.driver)
 }
 
 func (c *GenericClient) NextMedia(ctx context.Context) error {
 	return c.adapter.NextMedia(ctx, c.driver)
 }
 
+// WaitSettled waits for the current card to finish loading (see Settler),
+// or returns ErrUnsupported.
+func (c *GenericClient) WaitSettled(ctx context.Context) error {
+	st, ok := c.adapter.(Settler)
+	if !ok {
+		return fmt.Errorf("%w: %s settle wait", ErrUnsupported, c.adapter.Name())
+	}
+	return st.WaitSettled(ctx, c.driver)
+}
+
+// WaitMediaSettled waits for the photo shown after NextMedia (see Settler),
+// or returns ErrUnsupported.
+func (c *GenericClient) WaitMediaSettled(ctx context.Context) error {
+	st, ok := c.adapter.(Settler)
+	if !ok {
+		return fmt.Errorf("%w: %s settle wait", ErrUnsupported, c.adapter.Name())
+	}
+	return st.WaitMediaSettled(ctx, c.driver)
+}
+
+// AlbumLength reports how many photos the current card has (see
+// AlbumCounter), or returns ErrUnsupported.
+func (c *GenericClient) AlbumLength(ctx context.Context) (int, error) {
+	ac, ok := c.adapter.(AlbumCounter)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s album length", ErrUnsupported, c.adapter.Name())
+	}
+	return ac.AlbumLength(ctx, c.driver)
+}
+
 func (c *GenericClient) Screenshot(ctx context.Context, filePath string) error {
 	// return c.driver.Screenshot(ctx, filePath)
 	return c.adapter.ScreenshotMedia(ctx, c.driver, filePath)
 }
 
-func (c *GenericClient) Act(ctx context.Context, action domain.AppAction) error {
-	return c.adapter.Act(ctx, c.driver, action)
+// ScreenshotProfile captures the full profile card into filePath (see
+// ProfileCapturer), or returns ErrUnsupported.
+func (c *GenericClient) ScreenshotProfile(ctx context.Context, filePath string, keepSections bool) ([]string, error) {
+	pc, ok := c.adapter.(ProfileCapturer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s full-card capture", ErrUnsupported, c.adapter.Name())
+	}
+	return pc.ScreenshotProfile(ctx, c.driver, filePath, keepSections)
+}
+
+// Act applies action. A like or superswipe carrying a Message goes through
+// MessageActor when the adapter implements it (otherwise the message is
+// dropped); a message that fails after the action was applied is reported in
+// ActResult.MessageErr, not as an error. A like or superswipe that lands on
+// an out-of-likes or paywall screen comes back as a *domain.BlockedError
+// wrapping ErrNotApplied. After a like, adapters that implement MatchHandler
+// check for the match overlay and handle it per Config.OnMatch. Failures are
+// explained as in Open.
+func (c *GenericClient) Act(ctx context.Context, action domain.AppAction) (domain.ActResult, error) {
+	var res domain.ActResult
+	positive := action.Kind == domain.AppActionLike || action.Kind == domain.AppActionSuperSwipe
+	if ma, ok := c.adapter.(MessageActor); ok && positive && action.Message != "" {
+		sent, err := ma.ActWithMessage(ctx, c.driver, action)
+		if errors.Is(err, domain.ErrMessageNotSent) {
+			res.MessageErr = err
+		} else if err != nil {
+			return res, c.explain(ctx, err)
+		}
+		res.MessageSent = sent
+	} else if err := c.adapter.Act(ctx, c.driver, action); err != nil {
+		return res, c.explain(ctx, err)
+	}
+	if !positive {
+		return res, nil
+	}
+	// These blocks take the click without an error and keep the same card.
+	// An empty deck comes after an applied action, so it doesn't count.
+	if blk, err := c.Blocked(ctx); err == nil && blk != nil && blk.Kind != domain.BlockDeckEmpty {
+		blk.Err = ErrNotApplied
+		return res, blk
+	}
+	mh, ok := c.adapter.(MatchHandler)
+	if !ok {
+		return res, nil
+	}
+	mode := c.cfg.OnMatch
+	if mode == "" {
+		mode = domain.MatchDismiss
+	}
+	m, err := mh.HandleMatch(ctx, c.driver, mode)
+	if err != nil {
+		return res, fmt.Errorf("handle match: %w", err)
+	}
+	res.Match = m
+	return res, nil
+}
+
+// ReadBehaviour reads the current profile's text from the DOM when the
+// adapter supports it (see BehaviourReader), else returns ErrUnsupported.
+func (c *GenericClient) ReadBehaviour(ctx context.Context) (*domain.BehaviourTraits, error) {
+	r, ok := c.adapter.(BehaviourReader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s read behaviour", ErrUnsupported, c.adapter.Name())
+	}
+	return r.ReadBehaviour(ctx, c.driver)
+}
+
+func (c *GenericClient) messenger() (Messenger, error) {
+	m, ok := c.adapter.(Messenger)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s messaging", ErrUnsupported, c.adapter.Name())
+	}
+	return m, nil
+}
+
+// ListConversations lists the chats with matches (see Messenger), or returns
+// ErrUnsupported.
+func (c *GenericClient) ListConversations(ctx context.Context) ([]domain.Conversation, error) {
+	m, err := c.messenger()
+	if err != nil {
+		return nil, err
+	}
+	return m.ListConversations(ctx, c.driver)
+}
+
+// OpenConversation opens the chat with the given conversation id.
+func (c *GenericClient) OpenConversation(ctx context.Context, id string) error {
+	m, err := c.messenger()
+	if err != nil {
+		return err
+	}
+	return m.OpenConversation(ctx, c.driver, id)
+}
+
+// ReadConversation reads the open chat's message history.
+func (c *GenericClient) ReadConversation(ctx context.Context) (*domain.Conversation, error) {
+	m, err := c.messenger()
+	if err != nil {
+		return nil, err
+	}
+	return m.ReadConversation(ctx, c.driver)
+}
+
+// SendMessage sends text into the open chat.
+func (c *GenericClient) SendMessage(ctx context.Context, text string) error {
+	m, err := c.messenger()
+	if err != nil {
+		return err
+	}
+	return m.SendMessage(ctx, c.driver, text)
 }
@@ -0,0 +1,378 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/bumble"
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/apps/tinder"
+	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/swipeassist/internal/vault"
+)
+
+func newTestClient(fb *fakebrowser.Browser) (*GenericClient, *bumble.Adapter) {
+	cfg := engine.DefaultConfig()
+	cfg.StepTimeout = 200 * time.Millisecond
+	cfg.RetryAttempts = 1
+	cfg.RetryDelay = time.Millisecond
+	cfg.Human = engine.HumanConfig{Enabled: true, MinMoveSteps: 4, MaxMoveSteps: 8, CurveSpread: 0.25, ClickInset: 0.2, ScrollJitter: 10}
+
+	ad := bumble.NewAdapterFromDefaults()
+	return &GenericClient{
+		cfg:     Config{AppName: domain.Bumble},
+		adapter: ad,
+		driver:  engine.NewDriver(engine.NewWithBrowser(cfg, fb)),
+	}, ad
+}
+
+func TestGenericClientOpenUsesAdapterEntryURL(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	fb.Show(ad.S.ReadyHints...)
+
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if got := fb.Opened(); !reflect.DeepEqual(got, []string{ad.DefaultEntryURL()}) {
+		t.Fatalf("unexpected opened URLs: %v", got)
+	}
+}
+
+func TestGenericClientOpenRetriesNavigationTimeout(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.FailLoads(context.DeadlineExceeded)
+	c, ad := newTestClient(fb)
+	fb.Show(ad.S.ReadyHints...)
+
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if got := len(fb.Opened()); got != 2 {
+		t.Fatalf("expected a second navigation after timeout, got %d", got)
+	}
+}
+
+func TestGenericClientOpenFailsWhenNotReady(t *testing.T) {
+	t.Parallel()
+
+	c, _ := newTestClient(fakebrowser.New())
+
+	err := c.Open(context.Background())
+	if !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected ErrElementNotFound, got %v", err)
+	}
+}
+
+func TestGenericClientActDelegatesToAdapter(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	fb.Show(ad.S.ReadyHints...)
+	fb.Show(ad.S.Pass[0])
+
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	res, err := c.Act(context.Background(), domain.AppAction{Kind: domain.AppActionPass})
+	if err != nil {
+		t.Fatalf("Act returned error: %v", err)
+	}
+	if res.Match != nil {
+		t.Fatalf("pass must not look for a match, got %#v", res.Match)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{ad.S.Pass[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestGenericClientActReportsMatch(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	fb.Show(ad.S.ReadyHints...)
+	fb.Show(ad.S.Like[0], ad.S.MatchOverlay[0], ad.S.MatchChat[0])
+	fb.Set(ad.S.MatchName, fakebrowser.Node{Visible: true, Text: "Ana"})
+	c.cfg.OnMatch = domain.MatchChat
+
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	res, err := c.Act(context.Background(), domain.AppAction{Kind: domain.AppActionLike})
+	if err != nil {
+		t.Fatalf("Act returned error: %v", err)
+	}
+	if want := (&domain.Match{Name: "Ana", Mode: domain.MatchChat}); !reflect.DeepEqual(res.Match, want) {
+		t.Fatalf("Act match = %#v, want %#v", res.Match, want)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{ad.S.Like[0], ad.S.MatchChat[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestGenericClientActSendsMessage(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	ad.MatchWait = 10 * time.Millisecond
+	fb.Show(ad.S.ReadyHints...)
+	fb.Show(ad.S.ComplimentOpen[0], ad.S.ComplimentInput[0], ad.S.ComplimentSend[0])
+
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	res, err := c.Act(context.Background(), domain.AppAction{Kind: domain.AppActionLike, Message: "Nice dog!"})
+	if err != nil {
+		t.Fatalf("Act returned error: %v", err)
+	}
+	if !res.MessageSent {
+		t.Fatalf("expected MessageSent")
+	}
+	if got := fb.Typed(ad.S.ComplimentInput[0]); got != "Nice dog!" {
+		t.Fatalf("typed %q", got)
+	}
+}
+
+func TestGenericClientActReportsBlock(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	fb.Show(ad.S.ReadyHints...)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	// The paywall covers the controls, so the Like click finds nothing.
+	fb.Show(ad.S.Paywall[0])
+
+	_, err := c.Act(context.Background(), domain.AppAction{Kind: domain.AppActionLike})
+	var blk *domain.BlockedError
+	if !errors.As(err, &blk) || blk.Kind != domain.BlockPaywall {
+		t.Fatalf("expected paywall block, got %v", err)
+	}
+	if !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected the click failure to stay wrapped, got %v", err)
+	}
+}
+
+func TestGenericClientActReportsSwallowedLike(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	fb.Show(ad.S.ReadyHints...)
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	// The click lands, but the app answers with the out-of-likes view.
+	fb.Show(ad.S.Like[0])
+	fb.OnClick(ad.S.Like[0], func(b *fakebrowser.Browser) { b.Show(ad.S.OutOfLikes[0]) })
+
+	_, err := c.Act(context.Background(), domain.AppAction{Kind: domain.AppActionLike})
+	var blk *domain.BlockedError
+	if !errors.As(err, &blk) || blk.Kind != domain.BlockOutOfLikes || !errors.Is(err, ErrNotApplied) {
+		t.Fatalf("Act error = %v, want an out-of-likes block wrapping ErrNotApplied", err)
+	}
+}
+
+func TestGenericClientOpenReportsLoggedOut(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	fb.Show(ad.S.LoggedOut[0])
+
+	if err := c.Open(context.Background()); !errors.Is(err, ErrLoggedOut) {
+		t.Fatalf("expected ErrLoggedOut, got %v", err)
+	}
+	if state, err := c.CurrentState(context.Background()); err != nil || state != domain.UIStateLoggedOut {
+		t.Fatalf("CurrentState = %s, %v; want logged_out", state, err)
+	}
+}
+
+func TestGenericClientSavesSessionOnlyWhenReady(t *testing.T) {
+	t.Parallel()
+
+	for _, ready := range []bool{true, false} {
+		fb := fakebrowser.New()
+		fb.AddCookies(engine.Cookie{Name: "session", Value: "s3cret", Domain: ".bumble.com"})
+		path := filepath.Join(t.TempDir(), "session.json")
+		cfg := engine.DefaultConfig()
+		cfg.StepTimeout = 200 * time.Millisecond
+		cfg.SessionFile = path
+		ad := bumble.NewAdapterFromDefaults()
+		c := &GenericClient{cfg: Config{AppName: domain.Bumble}, adapter: ad, driver: engine.NewDriver(engine.NewWithBrowser(cfg, fb))}
+		if ready {
+			fb.Show(ad.S.ReadyHints...)
+		} else {
+			fb.Show(ad.S.LoggedOut[0])
+		}
+
+		_ = c.Open(context.Background())
+		if err := c.Close(); err != nil {
+			t.Fatalf("Close returned error: %v", err)
+		}
+		if _, err := os.Stat(path); (err == nil) != ready {
+			t.Fatalf("ready=%v: session file stat = %v", ready, err)
+		}
+	}
+}
+
+func newTestVault(t *testing.T, creds domain.Credentials, cookies ...engine.Cookie) *vault.Vault {
+	t.Helper()
+
+	v, err := vault.Open(filepath.Join(t.TempDir(), "vault.bin"), "test passphrase")
+	if err != nil {
+		t.Fatalf("vault.Open returned error: %v", err)
+	}
+	if !creds.Empty() {
+		v.SetCredentials(domain.Bumble, creds)
+	}
+	if len(cookies) > 0 {
+		v.SetCookies(domain.Bumble, cookies)
+	}
+	return v
+}
+
+func TestGenericClientOpenLogsInWithVault(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	ad.LoginWait = time.Second
+	c.vault = newTestVault(t, domain.Credentials{Phone: "+447700900123"})
+	c.cfg.OTP = func(context.Context) (string, error) { return "123456", nil }
+
+	fb.Show(ad.S.LoggedOut[0], ad.S.LoginPhone[0])
+	fb.Set(ad.S.LoginContinue[0], fakebrowser.Node{Visible: true, OnClick: func(b *fakebrowser.Browser) {
+		b.Show(ad.S.LoginCode[0], ad.S.LoginCodeSubmit[0])
+	}})
+	fb.Set(ad.S.LoginCodeSubmit[0], fakebrowser.Node{OnClick: func(b *fakebrowser.Browser) {
+		b.Remove(ad.S.LoggedOut[0], ad.S.LoginPhone[0], ad.S.LoginContinue[0], ad.S.LoginCode[0], ad.S.LoginCodeSubmit[0])
+		b.Show(ad.S.Card)
+		b.Show(ad.S.ReadyHints...)
+		b.AddCookies(engine.Cookie{Name: "session", Value: "fresh", Domain: ".bumble.com"})
+	}})
+
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if got := fb.Typed(ad.S.LoginCode[0]); got != "123456" {
+		t.Fatalf("typed code %q, want 123456", got)
+	}
+
+	saved, err := vault.Open(c.vault.Path(), "test passphrase")
+	if err != nil {
+		t.Fatalf("reopen vault returned error: %v", err)
+	}
+	if got := saved.Cookies(domain.Bumble); len(got) != 1 || got[0].Value != "fresh" {
+		t.Fatalf("vault cookies = %+v, want the new session", got)
+	}
+}
+
+func TestGenericClientOpenRestoresSavedSession(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	cookie := engine.Cookie{Name: "session", Value: "saved", Domain: ".bumble.com"}
+	c.vault = newTestVault(t, domain.Credentials{}, cookie)
+	fb.Show(ad.S.LoggedOut[0]) // the fake ignores cookies, so the session stays rejected
+
+	err := c.Open(context.Background())
+	if !errors.Is(err, ErrLoggedOut) || !strings.Contains(err.Error(), "no BUMBLE credentials") {
+		t.Fatalf("expected ErrLoggedOut for missing credentials, got %v", err)
+	}
+	if got := fb.Cookies(); !reflect.DeepEqual(got, []engine.Cookie{cookie}) {
+		t.Fatalf("browser cookies = %+v, want the saved session", got)
+	}
+	if got := len(fb.Opened()); got != 2 {
+		t.Fatalf("expected a reload after restoring cookies, got %d navigation(s)", got)
+	}
+}
+
+func TestGenericClientRecoverReopensAfterLostConnection(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	eng, err := engine.NewWithDialer(engine.DefaultConfig(), func() (engine.Browser, error) { return fb, nil })
+	if err != nil {
+		t.Fatalf("NewWithDialer returned error: %v", err)
+	}
+	c.driver = engine.NewDriver(eng)
+	fb.Show(ad.S.ReadyHints...)
+	ctx := context.Background()
+	if err := c.Open(ctx); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	if reconnected, err := c.Recover(ctx); reconnected || err != nil {
+		t.Fatalf("Recover on a live tab = %v, %v; want false, nil", reconnected, err)
+	}
+	fb.Disconnect()
+	reconnected, err := c.Recover(ctx)
+	if err != nil {
+		t.Fatalf("Recover returned error: %v", err)
+	}
+	if !reconnected {
+		t.Fatalf("Recover did not reconnect after the tab was lost")
+	}
+	if got, want := fb.Opened(), []string{ad.DefaultEntryURL(), ad.DefaultEntryURL()}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("opened = %v, want %v", got, want)
+	}
+}
+
+func TestGenericClientOpenSubscribesResponseCollector(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	c, ad := newTestClient(fb)
+	fb.Show(ad.S.ReadyHints...)
+
+	if err := c.Open(context.Background()); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	fb.RespondJSON("https://eu1.bumble.com/mwebapi.phtml?SERVER_GET_ENCOUNTERS",
+		[]byte(`{"body":[{"client_encounters":{"results":[{"user":{"user_id":"u1","name":"Ana","age":27}}]}}]}`))
+	if got := ad.Encounters(); len(got) != 1 || got[0].UserID != "u1" {
+		t.Fatalf("expected collected encounter, got %#v", got)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	fb.RespondJSON("https://eu1.bumble.com/mwebapi.phtml?SERVER_GET_ENCOUNTERS",
+		[]byte(`{"body":[{"client_encounters":{"results":[{"user":{"user_id":"u2"}}]}}]}`))
+	if got := ad.Encounters(); len(got) != 1 {
+		t.Fatalf("expected collection to stop on Close, got %#v", got)
+	}
+}
+
+func TestGenericClientMessagingUnsupported(t *testing.T) {
+	t.Parallel()
+
+	c, _ := newTestClient(fakebrowser.New())
+	c.adapter = tinder.NewAdapterFromDefaults()
+
+	if _, err := c.ListConversations(context.Background()); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+	if err := c.SendMessage(context.Background(), "hi"); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/internal/cardkit"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// ErrProfileUnidentified means the card has neither an id attribute nor a
+// readable name to hash.
+var ErrProfileUnidentified = fmt.Errorf("declarative: %w", domain.ErrProfileUnidentified)
+
+// Adapter implements apps.Adapter from a Spec.
+type Adapter struct {
+	S Spec
+}
+
+// New returns an adapter for a loaded spec.
+func New(s Spec) *Adapter { return &Adapter{S: s} }
+
+func (a *Adapter) Name() string { return a.S.Name }
+
+func (a *Adapter) DefaultEntryURL() string { return a.S.EntryURL }
+
+func (a *Adapter) WaitReady(ctx context.Context, d engine.IDriver) error {
+	return d.WaitAnyVisible(ctx, a.S.ReadyHints)
+}
+
+func (a *Adapter) NextMedia(ctx context.Context, d engine.IDriver) error {
+	if len(a.S.NextMedia.Disabled) > 0 {
+		disabled, err := d.IsVisible(ctx, a.S.NextMedia.Disabled)
+		if err != nil {
+			return err
+		}
+		if disabled {
+			return fmt.Errorf("next media navigation is disabled")
+		}
+	}
+	return d.ClickBySelectors(ctx, a.S.NextMedia.Click)
+}
+
+// Act clicks the selectors configured for the action kind. A kind the spec
+// has no selectors for is an error, so a missing config line never passes
+// silently for a swipe.
+func (a *Adapter) Act(ctx context.Context, d engine.IDriver, action domain.AppAction) error {
+	sels, ok := a.S.Actions[action.Kind]
+	if !ok {
+		return fmt.Errorf("%s: no selectors for action %s", a.S.Name, action.Kind)
+	}
+	return d.ClickBySelectors(ctx, sels)
+}
+
+func (a *Adapter) ScreenshotMedia(ctx context.Context, d engine.IDriver, filePath string) error {
+	return d.ScreenshotElement(ctx, a.S.MediaCapture, filePath)
+}
+
+// GetProfileId returns "<name>_<id>" from the first configured id attribute on
+// the card root, else "<name>_h<hash>" of name, age and photo URL (without
+// query string). Call it before paging the album.
+func (a *Adapter) GetProfileId(ctx context.Context, d engine.IDriver) (string, error) {
+	c := a.S.Card
+	if c.Root != "" {
+		for _, attr := range c.IDAttrs {
+			id, ok, err := d.Attribute(ctx, c.Root, attr)
+			if err != nil {
+				return "", fmt.Errorf("read %s: %w", attr, err)
+			}
+			if id = strings.TrimSpace(id); ok && id != "" {
+				return a.profileKey(id), nil
+			}
+		}
+	}
+
+	name, err := cardkit.FirstText(ctx, d, c.Name)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", ErrProfileUnidentified
+	}
+	ageText, err := cardkit.FirstText(ctx, d, c.Age)
+	if err != nil {
+		return "", err
+	}
+
+	var photo string
+	if c.Photo != "" {
+		if photo, _, err = d.Attribute(ctx, c.Photo, c.PhotoAttr); err != nil {
+			return "", fmt.Errorf("read photo %s: %w", c.PhotoAttr, err)
+		}
+	}
+	return cardkit.HashKey(a.S.Name+"_", name, cardkit.Digits(ageText), cardkit.StripQuery(photo)), nil
+}
+
+// ReadProfileCard reads the configured card facts. Unconfigured facts stay
+// zero; only a missing name is an error.
+func (a *Adapter) ReadProfileCard(ctx context.Context, d engine.IDriver) (*domain.ProfileCard, error) {
+	c := a.S.Card
+	return cardkit.Read(ctx, d, cardkit.Fields{
+		Name:           c.Name,
+		Age:            c.Age,
+		Distance:       c.Distance,
+		Location:       c.Location,
+		Job:            c.Job,
+		Education:      c.Education,
+		Verified:       c.Verified,
+		RecentlyActive: c.RecentlyActive,
+		Premium:        c.Premium,
+	}, ErrProfileUnidentified)
+}
+
+func (a *Adapter) profileKey(id string) string { return cardkit.Key(a.S.Name+"_", id) }
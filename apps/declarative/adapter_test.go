@@ -0,0 +1,122 @@
+package declarative
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/apps/internal/apptest"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func newTestAdapter(t *testing.T) *Adapter {
+	t.Helper()
+	s, err := Load("testdata/demo.yaml")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	return New(s)
+}
+
+func newTestDriver(t *testing.T, fb *fakebrowser.Browser) engine.IDriver {
+	return apptest.NewDriver(t, fb, "https://demo.test/app")
+}
+
+func TestAdapterNextMedia(t *testing.T) {
+	t.Parallel()
+
+	a := newTestAdapter(t)
+	fb := fakebrowser.New()
+	fb.Show("button.next")
+	d := newTestDriver(t, fb)
+
+	if err := a.NextMedia(context.Background(), d); err != nil {
+		t.Fatalf("NextMedia returned error: %v", err)
+	}
+	fb.Show("button.next[disabled]")
+	if err := a.NextMedia(context.Background(), d); err == nil {
+		t.Fatalf("expected error when next navigation is disabled")
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{"button.next"}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestAdapterActUsesConfiguredSelectors(t *testing.T) {
+	t.Parallel()
+
+	a := newTestAdapter(t)
+	fb := fakebrowser.New()
+	fb.Show("div.like", "button.nope")
+	d := newTestDriver(t, fb)
+
+	for _, kind := range []domain.AppActionType{domain.AppActionLike, domain.AppActionPass} {
+		if err := a.Act(context.Background(), d, domain.AppAction{Kind: kind}); err != nil {
+			t.Fatalf("Act(%s) returned error: %v", kind, err)
+		}
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{"div.like", "button.nope"}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+
+	err := a.Act(context.Background(), d, domain.AppAction{Kind: domain.AppActionSuperSwipe})
+	if err == nil || !strings.Contains(err.Error(), "no selectors for action SUPERSWIPE") {
+		t.Fatalf("expected missing-action error, got %v", err)
+	}
+}
+
+func TestAdapterGetProfileId(t *testing.T) {
+	t.Parallel()
+
+	a := newTestAdapter(t)
+	fb := fakebrowser.New()
+	fb.Set("h1.name", fakebrowser.Node{Visible: true, Text: "Maya"})
+	fb.Set("span.age", fakebrowser.Node{Visible: true, Text: "29"})
+	fb.Set("img.photo", fakebrowser.Node{Visible: true, Attrs: map[string]string{"src": "https://cdn.test/1.jpg?sig=a"}})
+	d := newTestDriver(t, fb)
+
+	hashed, err := a.GetProfileId(context.Background(), d)
+	if err != nil {
+		t.Fatalf("GetProfileId returned error: %v", err)
+	}
+	if !strings.HasPrefix(hashed, "demo_h") {
+		t.Fatalf("expected hashed key, got %q", hashed)
+	}
+
+	fb.Set("div.card", fakebrowser.Node{Visible: true, Attrs: map[string]string{"data-id": "u 42"}})
+	got, err := a.GetProfileId(context.Background(), d)
+	if err != nil {
+		t.Fatalf("GetProfileId returned error: %v", err)
+	}
+	if got != "demo_u-42" {
+		t.Fatalf("GetProfileId = %q, want demo_u-42", got)
+	}
+}
+
+func TestAdapterReadProfileCard(t *testing.T) {
+	t.Parallel()
+
+	a := newTestAdapter(t)
+	fb := fakebrowser.New()
+	if _, err := a.ReadProfileCard(context.Background(), newTestDriver(t, fb)); !errors.Is(err, ErrProfileUnidentified) {
+		t.Fatalf("expected ErrProfileUnidentified, got %v", err)
+	}
+
+	fb.Set("h1.name", fakebrowser.Node{Visible: true, Text: "Maya"})
+	fb.Set("span.age", fakebrowser.Node{Visible: true, Text: ", 29"})
+	fb.Set("p.town", fakebrowser.Node{Visible: true, Text: "Leeds"})
+	fb.Set("i.tick", fakebrowser.Node{Visible: true})
+
+	got, err := a.ReadProfileCard(context.Background(), newTestDriver(t, fb))
+	if err != nil {
+		t.Fatalf("ReadProfileCard returned error: %v", err)
+	}
+	want := &domain.ProfileCard{Name: "Maya", Age: 29, Location: "Leeds", Verified: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadProfileCard = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,194 @@
+// Package declarative builds app adapters from YAML specs, so selector fixes
+// and new apps are config changes instead of code changes.
+package declarative
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// Spec describes an adapter. Selector lists are tried in order; the first
+// visible match wins.
+type Spec struct {
+	Path     string         `yaml:"-"` // file the spec was loaded from
+	App      domain.AppName `yaml:"app"`
+	Name     string         `yaml:"name"` // adapter name and profile key prefix; defaults to the lowercased app
+	EntryURL string         `yaml:"entry_url"`
+
+	ReadyHints []string `yaml:"ready_hints"`
+
+	NextMedia struct {
+		Click    []string `yaml:"click"`
+		Disabled []string `yaml:"disabled"` // visible when the album is on its last photo
+	} `yaml:"next_media"`
+
+	Actions map[domain.AppActionType][]string `yaml:"actions"`
+
+	MediaCapture string `yaml:"media_capture"` // element screenshotted per photo
+
+	Card CardSpec `yaml:"card"`
+}
+
+// CardSpec holds the selectors for the card on screen.
+type CardSpec struct {
+	Root      string   `yaml:"root"`
+	IDAttrs   []string `yaml:"id_attrs"` // attributes on Root carrying the user id
+	Name      string   `yaml:"name"`
+	Age       string   `yaml:"age"`
+	Photo     string   `yaml:"photo"`
+	PhotoAttr string   `yaml:"photo_attr"` // attribute on Photo holding its URL; defaults to src
+
+	Distance       string `yaml:"distance"`
+	Location       string `yaml:"location"`
+	Job            string `yaml:"job"`
+	Education      string `yaml:"education"`
+	Verified       string `yaml:"verified"`
+	RecentlyActive string `yaml:"recently_active"`
+	Premium        string `yaml:"premium"`
+}
+
+var knownActions = map[domain.AppActionType]bool{
+	domain.AppActionPass:       true,
+	domain.AppActionLike:       true,
+	domain.AppActionSuperSwipe: true,
+	domain.AppActionUndo:       true,
+}
+
+// SpecError is a spec file that failed to load. App is the app the file
+// names, or empty when it can't be read that far.
+type SpecError struct {
+	Path string
+	App  domain.AppName
+	Err  error
+}
+
+func (e *SpecError) Error() string { return e.Err.Error() }
+
+func (e *SpecError) Unwrap() error { return e.Err }
+
+// Overlay sets *dst to v unless v is empty; built-in adapters use it to take
+// the selectors a spec for their app sets and keep their defaults for the
+// rest.
+func Overlay[T string | []string](dst *T, v T) {
+	if len(v) > 0 {
+		*dst = v
+	}
+}
+
+// Load reads and validates the spec at path.
+func Load(path string) (Spec, error) {
+	var s Spec
+	f, err := os.Open(path)
+	if err != nil {
+		return s, err
+	}
+	defer f.Close()
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil {
+		return s, fmt.Errorf("parse %s: %w", path, err)
+	}
+	s.Path = path
+	s.App = domain.AppName(strings.ToUpper(strings.TrimSpace(string(s.App))))
+	if s.Name == "" {
+		s.Name = strings.ToLower(string(s.App))
+	}
+	if s.Card.PhotoAttr == "" {
+		s.Card.PhotoAttr = "src"
+	}
+	if err := s.validate(); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// LoadDir loads every *.yaml and *.yml spec in dir, in file name order. A
+// missing dir is not an error. Specs that fail to load, and a second spec
+// for the same app, are skipped and reported as *SpecError values joined
+// into the error; the specs that loaded are returned either way.
+func LoadDir(dir string) ([]Spec, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if ext := filepath.Ext(e.Name()); !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	specs := make([]Spec, 0, len(names))
+	seen := map[domain.AppName]string{}
+	var errs []error
+	for _, n := range names {
+		path := filepath.Join(dir, n)
+		s, err := Load(path)
+		if err != nil {
+			errs = append(errs, &SpecError{Path: path, App: peekApp(path), Err: err})
+			continue
+		}
+		if prev, dup := seen[s.App]; dup {
+			errs = append(errs, &SpecError{Path: path, App: s.App, Err: fmt.Errorf("%s: app %s already defined in %s", n, s.App, prev)})
+			continue
+		}
+		seen[s.App] = n
+		specs = append(specs, s)
+	}
+	return specs, errors.Join(errs...)
+}
+
+// peekApp returns the app a spec file names, ignoring everything else in it.
+func peekApp(path string) domain.AppName {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var s struct {
+		App string `yaml:"app"`
+	}
+	_ = yaml.Unmarshal(b, &s)
+	return domain.AppName(strings.ToUpper(strings.TrimSpace(s.App)))
+}
+
+func (s Spec) validate() error {
+	var missing []string
+	for field, empty := range map[string]bool{
+		"app":              s.App == "",
+		"entry_url":        s.EntryURL == "",
+		"ready_hints":      len(s.ReadyHints) == 0,
+		"next_media.click": len(s.NextMedia.Click) == 0,
+		"media_capture":    s.MediaCapture == "",
+		"card.name":        s.Card.Name == "",
+	} {
+		if empty {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	for kind, sels := range s.Actions {
+		if !knownActions[kind] {
+			return fmt.Errorf("unknown action %q", kind)
+		}
+		if len(sels) == 0 {
+			return fmt.Errorf("action %s has no selectors", kind)
+		}
+	}
+	return nil
+}
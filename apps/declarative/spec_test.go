@@ -0,0 +1,117 @@
+package declarative
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func writeSpec(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestLoadSpec(t *testing.T) {
+	t.Parallel()
+
+	s, err := Load("testdata/demo.yaml")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if s.App != "DEMO" || s.Name != "demo" {
+		t.Fatalf("app/name = %q/%q, want DEMO/demo", s.App, s.Name)
+	}
+	if s.Card.PhotoAttr != "src" {
+		t.Fatalf("photo_attr default = %q, want src", s.Card.PhotoAttr)
+	}
+	want := map[domain.AppActionType][]string{
+		domain.AppActionPass: {"button.nope"},
+		domain.AppActionLike: {"button.like", "div.like"},
+	}
+	if !reflect.DeepEqual(s.Actions, want) {
+		t.Fatalf("actions = %#v, want %#v", s.Actions, want)
+	}
+	if !reflect.DeepEqual(s.NextMedia.Disabled, []string{"button.next[disabled]"}) {
+		t.Fatalf("next_media.disabled = %v", s.NextMedia.Disabled)
+	}
+}
+
+func TestLoadSpecRejectsInvalid(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	base, err := os.ReadFile("testdata/demo.yaml")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"missing fields": {`app: "x"`, "missing card.name, entry_url, media_capture, next_media.click, ready_hints"},
+		"unknown action": {strings.Replace(string(base), "  LIKE:", "  WAVE: [\"b\"]\n  LIKE:", 1), `unknown action "WAVE"`},
+		"unknown field":  {string(base) + "swipe_delay: 3\n", "field swipe_delay not found"},
+	}
+	for name, tc := range cases {
+		path := writeSpec(t, dir, strings.ReplaceAll(name, " ", "_")+".yaml", tc.body)
+		_, err := Load(path)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: Load error = %v, want %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	t.Parallel()
+
+	if specs, err := LoadDir(filepath.Join(t.TempDir(), "missing")); err != nil || specs != nil {
+		t.Fatalf("LoadDir(missing) = %v, %v; want nil, nil", specs, err)
+	}
+
+	dir := t.TempDir()
+	base, err := os.ReadFile("testdata/demo.yaml")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	writeSpec(t, dir, "b.yml", strings.Replace(string(base), `app: "demo"`, `app: "other"`, 1))
+	writeSpec(t, dir, "a.yaml", string(base))
+	writeSpec(t, dir, "notes.txt", "not a spec")
+
+	specs, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir returned error: %v", err)
+	}
+	if len(specs) != 2 || specs[0].App != "DEMO" || specs[1].App != "OTHER" {
+		t.Fatalf("LoadDir = %+v", specs)
+	}
+
+	writeSpec(t, dir, "c.yaml", string(base))
+	writeSpec(t, dir, "d.yaml", "app: broken\n")
+	specs, err = LoadDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "already defined in a.yaml") {
+		t.Fatalf("expected duplicate app error, got %v", err)
+	}
+	if len(specs) != 2 {
+		t.Fatalf("LoadDir kept %d specs past the bad ones, want 2", len(specs))
+	}
+	var apps []domain.AppName
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var se *SpecError
+		if !errors.As(e, &se) {
+			t.Fatalf("error %v is not a *SpecError", e)
+		}
+		apps = append(apps, se.App)
+	}
+	if !reflect.DeepEqual(apps, []domain.AppName{"DEMO", "BROKEN"}) {
+		t.Fatalf("SpecError apps = %v, want [DEMO BROKEN]", apps)
+	}
+}
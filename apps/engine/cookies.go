@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Cookie is a browser cookie as read from or written to the open page.
+// A zero Expires is a session cookie.
+type Cookie struct {
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Domain   string    `json:"domain"`
+	Path     string    `json:"path,omitempty"`
+	Expires  time.Time `json:"expires,omitzero"`
+	HTTPOnly bool      `json:"http_only,omitempty"`
+	Secure   bool      `json:"secure,omitempty"`
+	SameSite string    `json:"same_site,omitempty"`
+}
+
+func (d *Driver) Cookies(ctx context.Context) ([]Cookie, error) {
+	start := time.Now()
+	cookies, err := d.cookies(ctx)
+	d.tracer.Record(TraceEntry{Op: TraceOpCookies, Cookies: redactCookies(cookies)}, start, err)
+	return cookies, err
+}
+
+func (d *Driver) cookies(ctx context.Context) ([]Cookie, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := d.e.RequirePage(); err != nil {
+		return nil, err
+	}
+	return d.e.page.Cookies()
+}
+
+func (d *Driver) SetCookies(ctx context.Context, cookies []Cookie) error {
+	start := time.Now()
+	err := ctx.Err()
+	if err == nil {
+		err = d.e.RequirePage()
+	}
+	if err == nil && len(cookies) > 0 {
+		err = d.e.page.SetCookies(cookies)
+	}
+	d.tracer.Record(TraceEntry{Op: TraceOpSetCookies, Cookies: redactCookies(cookies)}, start, err)
+	return err
+}
+
+// redactCookies drops the values so traces, which get shared, never carry a
+// live session.
+func redactCookies(cookies []Cookie) []Cookie {
+	if len(cookies) == 0 {
+		return nil
+	}
+	out := make([]Cookie, len(cookies))
+	for i, c := range cookies {
+		c.Value = ""
+		out[i] = c
+	}
+	return out
+}
+
+func cookieFromProto(c *proto.NetworkCookie) Cookie {
+	out := Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		HTTPOnly: c.HTTPOnly,
+		Secure:   c.Secure,
+		SameSite: string(c.SameSite),
+	}
+	if !c.Session && c.Expires > 0 {
+		out.Expires = c.Expires.Time()
+	}
+	return out
+}
+
+func cookieToProto(c Cookie) *proto.NetworkCookieParam {
+	p := &proto.NetworkCookieParam{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		HTTPOnly: c.HTTPOnly,
+		Secure:   c.Secure,
+		SameSite: proto.NetworkCookieSameSite(c.SameSite),
+	}
+	if !c.Expires.IsZero() {
+		p.Expires = proto.TimeSinceEpoch(float64(c.Expires.UnixNano()) / 1e9)
+	}
+	return p
+}
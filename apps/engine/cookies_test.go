@@ -0,0 +1,81 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func TestDriverSetCookiesThenCookies(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	drv, _ := newTestDriver(t, fb)
+	ctx := context.Background()
+
+	want := []engine.Cookie{{Name: "session", Value: "s3cret", Domain: ".example.test", Path: "/", Secure: true}}
+	if err := drv.SetCookies(ctx, want); err != nil {
+		t.Fatalf("SetCookies returned error: %v", err)
+	}
+	got, err := drv.Cookies(ctx)
+	if err != nil {
+		t.Fatalf("Cookies returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("cookies = %+v, want %+v", got, want)
+	}
+}
+
+func TestDriverCookiesRequireOpenPage(t *testing.T) {
+	t.Parallel()
+
+	drv := engine.NewDriver(engine.NewWithBrowser(engine.DefaultConfig(), fakebrowser.New()))
+	if _, err := drv.Cookies(context.Background()); !errors.Is(err, engine.ErrPageNotOpen) {
+		t.Fatalf("expected ErrPageNotOpen, got %v", err)
+	}
+}
+
+func TestTraceRedactsCookieValues(t *testing.T) {
+	t.Parallel()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	fb := fakebrowser.New()
+	fb.AddCookies(engine.Cookie{Name: "session", Value: "s3cret", Domain: ".example.test"})
+	drv, _ := newTestDriver(t, fb)
+	if err := drv.StartTrace(tracePath); err != nil {
+		t.Fatalf("StartTrace returned error: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := drv.Cookies(ctx); err != nil {
+		t.Fatalf("Cookies returned error: %v", err)
+	}
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	entries, err := engine.LoadTrace(tracePath)
+	if err != nil {
+		t.Fatalf("LoadTrace returned error: %v", err)
+	}
+	last := entries[len(entries)-1]
+	if last.Op != engine.TraceOpCookies || len(last.Cookies) != 1 {
+		t.Fatalf("unexpected last entry: %+v", last)
+	}
+	if c := last.Cookies[0]; c.Name != "session" || c.Value != "" {
+		t.Fatalf("trace kept cookie %+v, want name only", c)
+	}
+
+	rd, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	got, err := rd.Cookies(ctx)
+	if err != nil || len(got) != 1 || got[0].Name != "session" {
+		t.Fatalf("replay Cookies = %+v, %v", got, err)
+	}
+}
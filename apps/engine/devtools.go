@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// discoverTimeout bounds the /json/version request of ResolveControlURL.
+const discoverTimeout = 5 * time.Second
+
+var portOnly = regexp.MustCompile(`^:?(\d+)$`)
+
+// ResolveControlURL turns a DevTools address into the browser's websocket
+// URL. addr may be a port ("9222"), "host:port", an http(s) URL, or a
+// ws(s) URL; a ws URL that already names a /devtools/browser/ target is
+// returned as is. The others are looked up at /json/version, and the host
+// in the answer is replaced with addr's so tunnels and port forwards work.
+func ResolveControlURL(ctx context.Context, addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if m := portOnly.FindStringSubmatch(addr); m != nil {
+		addr = "127.0.0.1:" + m[1]
+	}
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("%w: bad DevTools address %q", ErrConnectionFailed, addr)
+	}
+	switch u.Scheme {
+	case "ws", "wss":
+		if strings.HasPrefix(u.Path, "/devtools/browser/") {
+			return u.String(), nil
+		}
+		u.Scheme = strings.Replace(u.Scheme, "ws", "http", 1)
+	case "http", "https":
+	default:
+		return "", fmt.Errorf("%w: bad DevTools address %q", ErrConnectionFailed, addr)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, discoverTimeout)
+	defer cancel()
+	version := url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/json/version"}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, version.String(), nil)
+	if err != nil {
+		return "", wrapConnect(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", wrapConnect(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s: %s", ErrConnectionFailed, version.String(), res.Status)
+	}
+	var info struct {
+		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("%w: decode %s: %w", ErrConnectionFailed, version.String(), err)
+	}
+	ws, err := url.Parse(info.WebSocketDebuggerURL)
+	if err != nil || info.WebSocketDebuggerURL == "" {
+		return "", fmt.Errorf("%w: %s has no webSocketDebuggerUrl", ErrConnectionFailed, version.String())
+	}
+	ws.Host = u.Host
+	if u.Scheme == "https" {
+		ws.Scheme = "wss"
+	}
+	return ws.String(), nil
+}
@@ -0,0 +1,66 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+)
+
+func TestResolveControlURLFromHostPort(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"Browser":"Chrome/140","webSocketDebuggerUrl":"ws://localhost:9222/devtools/browser/abc-123"}`))
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+	port := host[strings.LastIndex(host, ":")+1:]
+
+	for _, addr := range []string{host, srv.URL, "ws://" + host, port} {
+		got, err := engine.ResolveControlURL(context.Background(), addr)
+		if err != nil {
+			t.Fatalf("ResolveControlURL(%q) returned error: %v", addr, err)
+		}
+		want := "ws://" + host + "/devtools/browser/abc-123"
+		if addr == port {
+			want = "ws://127.0.0.1:" + port + "/devtools/browser/abc-123"
+		}
+		if got != want {
+			t.Fatalf("ResolveControlURL(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
+
+func TestResolveControlURLKeepsBrowserURL(t *testing.T) {
+	t.Parallel()
+
+	const ws = "ws://127.0.0.1:1/devtools/browser/abc-123"
+	got, err := engine.ResolveControlURL(context.Background(), ws)
+	if err != nil {
+		t.Fatalf("ResolveControlURL returned error: %v", err)
+	}
+	if got != ws {
+		t.Fatalf("ResolveControlURL = %q, want %q unchanged", got, ws)
+	}
+}
+
+func TestResolveControlURLReportsMissingEndpoint(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	_, err := engine.ResolveControlURL(context.Background(), srv.URL)
+	if !errors.Is(err, engine.ErrConnectionFailed) {
+		t.Fatalf("expected ErrConnectionFailed, got %v", err)
+	}
+}
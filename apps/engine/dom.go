@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+func (d *Driver) Texts(ctx context.Context, selector string) ([]string, error) {
+	start := time.Now()
+	texts, err := d.texts(ctx, selector)
+	d.tracer.Record(TraceEntry{Op: TraceOpTexts, Selectors: []string{selector}, Texts: texts}, start, err)
+	return texts, err
+}
+
+func (d *Driver) texts(ctx context.Context, selector string) ([]string, error) {
+	els, err := d.elements(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(els))
+	for _, el := range els {
+		txt, err := el.Text()
+		if err != nil {
+			return nil, err
+		}
+		if txt = strings.TrimSpace(txt); txt != "" {
+			out = append(out, txt)
+		}
+	}
+	return out, nil
+}
+
+func (d *Driver) Attribute(ctx context.Context, selector, name string) (string, bool, error) {
+	start := time.Now()
+	v, err := d.attribute(ctx, selector, name)
+	d.tracer.Record(TraceEntry{Op: TraceOpAttribute, Selectors: []string{selector}, Attr: name, Value: v}, start, err)
+	if v == nil {
+		return "", false, err
+	}
+	return *v, true, err
+}
+
+func (d *Driver) attribute(ctx context.Context, selector, name string) (*string, error) {
+	els, err := d.elements(ctx, selector)
+	if err != nil || len(els) == 0 {
+		return nil, err
+	}
+	return els[0].Attribute(name)
+}
+
+func (d *Driver) Records(ctx context.Context, selector string, fields map[string]string) ([]map[string]string, error) {
+	start := time.Now()
+	recs, err := d.records(ctx, selector, fields)
+	d.tracer.Record(TraceEntry{Op: TraceOpRecords, Selectors: []string{selector}, Fields: fields, Records: recs}, start, err)
+	return recs, err
+}
+
+func (d *Driver) records(ctx context.Context, selector string, fields map[string]string) ([]map[string]string, error) {
+	els, err := d.elements(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]map[string]string, 0, len(els))
+	for _, el := range els {
+		rec := make(map[string]string, len(fields))
+		for field, sub := range fields {
+			v, err := readField(el, sub)
+			if err != nil {
+				return nil, err
+			}
+			rec[field] = v
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
+
+// readField reads "@name" as an attribute of el, anything else as the text of
+// the first descendant matching it.
+func readField(el Element, sub string) (string, error) {
+	if name, ok := strings.CutPrefix(sub, "@"); ok {
+		v, err := el.Attribute(name)
+		if err != nil || v == nil {
+			return "", err
+		}
+		return strings.TrimSpace(*v), nil
+	}
+	children, err := el.Elements(sub)
+	if err != nil || len(children) == 0 {
+		return "", err
+	}
+	txt, err := children[0].Text()
+	return strings.TrimSpace(txt), err
+}
+
+func (d *Driver) elements(ctx context.Context, selector string) ([]Element, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := d.e.RequirePage(); err != nil {
+		return nil, err
+	}
+	return d.e.page.Elements(selector)
+}
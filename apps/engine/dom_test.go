@@ -0,0 +1,179 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func newStoryBrowser() *fakebrowser.Browser {
+	fb := fakebrowser.New()
+	fb.SetAll(".about", fakebrowser.Node{Text: "  Coffee first. "}, fakebrowser.Node{Text: "   "}, fakebrowser.Node{Text: "Then hikes."})
+	fb.SetAll("li.pill",
+		fakebrowser.Node{
+			Attrs:    map[string]string{"data-qa-type": "height"},
+			Children: map[string][]fakebrowser.Node{".title": {{Text: "170 cm"}}},
+		},
+		fakebrowser.Node{
+			Children: map[string][]fakebrowser.Node{".title": {{Text: "Dog lover"}}},
+		},
+	)
+	fb.Set("#card", fakebrowser.Node{Visible: true, Attrs: map[string]string{"data-profile-id": "p-42"}})
+	return fb
+}
+
+func TestDriverTextsTrimsAndSkipsEmpty(t *testing.T) {
+	t.Parallel()
+
+	drv, _ := newTestDriver(t, newStoryBrowser())
+
+	got, err := drv.Texts(context.Background(), ".about")
+	if err != nil {
+		t.Fatalf("Texts returned error: %v", err)
+	}
+	if want := []string{"Coffee first.", "Then hikes."}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Texts = %q, want %q", got, want)
+	}
+
+	got, err = drv.Texts(context.Background(), ".missing")
+	if err != nil || len(got) != 0 {
+		t.Fatalf("expected no texts for missing selector, got %q, %v", got, err)
+	}
+}
+
+func TestDriverAttribute(t *testing.T) {
+	t.Parallel()
+
+	drv, _ := newTestDriver(t, newStoryBrowser())
+	ctx := context.Background()
+
+	v, ok, err := drv.Attribute(ctx, "#card", "data-profile-id")
+	if err != nil || !ok || v != "p-42" {
+		t.Fatalf("Attribute = %q, %v, %v; want p-42", v, ok, err)
+	}
+	if _, ok, err := drv.Attribute(ctx, "#card", "data-missing"); ok || err != nil {
+		t.Fatalf("expected absent attribute, got ok=%v err=%v", ok, err)
+	}
+	if _, ok, err := drv.Attribute(ctx, "#nope", "id"); ok || err != nil {
+		t.Fatalf("expected absent element, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestDriverRecordsReadsFieldsPerElement(t *testing.T) {
+	t.Parallel()
+
+	drv, _ := newTestDriver(t, newStoryBrowser())
+
+	got, err := drv.Records(context.Background(), "li.pill", map[string]string{"key": "@data-qa-type", "value": ".title"})
+	if err != nil {
+		t.Fatalf("Records returned error: %v", err)
+	}
+	want := []map[string]string{
+		{"key": "height", "value": "170 cm"},
+		{"key": "", "value": "Dog lover"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Records = %v, want %v", got, want)
+	}
+}
+
+func TestDriverDOMReadsRequireOpenPage(t *testing.T) {
+	t.Parallel()
+
+	drv := engine.NewDriver(engine.NewWithBrowser(engine.DefaultConfig(), fakebrowser.New()))
+	if _, err := drv.Texts(context.Background(), ".about"); !errors.Is(err, engine.ErrPageNotOpen) {
+		t.Fatalf("expected ErrPageNotOpen, got %v", err)
+	}
+}
+
+func TestReplayDriverReproducesDOMReads(t *testing.T) {
+	t.Parallel()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	fields := map[string]string{"key": "@data-qa-type", "value": ".title"}
+	ctx := context.Background()
+
+	drv, _ := newTestDriver(t, newStoryBrowser())
+	if err := drv.StartTrace(tracePath); err != nil {
+		t.Fatalf("StartTrace returned error: %v", err)
+	}
+	texts, _ := drv.Texts(ctx, ".about")
+	id, _, _ := drv.Attribute(ctx, "#card", "data-profile-id")
+	recs, _ := drv.Records(ctx, "li.pill", fields)
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	rp, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	gotTexts, err := rp.Texts(ctx, ".about")
+	if err != nil || !reflect.DeepEqual(gotTexts, texts) {
+		t.Fatalf("replayed Texts = %q, %v; want %q", gotTexts, err, texts)
+	}
+	gotID, ok, err := rp.Attribute(ctx, "#card", "data-profile-id")
+	if err != nil || !ok || gotID != id {
+		t.Fatalf("replayed Attribute = %q, %v, %v; want %q", gotID, ok, err, id)
+	}
+	gotRecs, err := rp.Records(ctx, "li.pill", fields)
+	if err != nil || !reflect.DeepEqual(gotRecs, recs) {
+		t.Fatalf("replayed Records = %v, %v; want %v", gotRecs, err, recs)
+	}
+	if rp.Remaining() != 0 {
+		t.Fatalf("expected trace to be fully replayed, %d calls left", rp.Remaining())
+	}
+}
+
+func TestReplayDriverAttributeNameMismatch(t *testing.T) {
+	t.Parallel()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	ctx := context.Background()
+
+	drv, _ := newTestDriver(t, newStoryBrowser())
+	if err := drv.StartTrace(tracePath); err != nil {
+		t.Fatalf("StartTrace returned error: %v", err)
+	}
+	_, _, _ = drv.Attribute(ctx, "#card", "data-profile-id")
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	rp, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	if _, _, err := rp.Attribute(ctx, "#card", "data-other"); !errors.Is(err, engine.ErrTraceMismatch) {
+		t.Fatalf("expected ErrTraceMismatch for a different attribute, got %v", err)
+	}
+}
+
+func TestReplayDriverRecordsFieldsMismatch(t *testing.T) {
+	t.Parallel()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	ctx := context.Background()
+
+	drv, _ := newTestDriver(t, newStoryBrowser())
+	if err := drv.StartTrace(tracePath); err != nil {
+		t.Fatalf("StartTrace returned error: %v", err)
+	}
+	_, _ = drv.Records(ctx, "li.pill", map[string]string{"kind": "@data-kind"})
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	rp, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	if _, err := rp.Records(ctx, "li.pill", map[string]string{"kind": "@data-other"}); !errors.Is(err, engine.ErrTraceMismatch) {
+		t.Fatalf("expected ErrTraceMismatch for different fields, got %v", err)
+	}
+}
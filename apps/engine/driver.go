@@ -10,38 +10,82 @@ import (
 )
 
 type Driver struct {
-	e *Engine
+	e       *Engine
+	tracer  *Tracer
+	watches int
 }
 
 func NewDriver(e *Engine) *Driver { return &Driver{e: e} }
 
-func (d *Driver) Close() { d.e.Close() }
-
-func (d *Driver) Open(ctx context.Context, url string) error {
-	if err := ctx.Err(); err != nil {
+// StartTrace records every driver call from now on to a JSON-lines trace at
+// path (see Tracer). Close flushes and closes it.
+func (d *Driver) StartTrace(path string) error {
+	t, err := NewTracer(path)
+	if err != nil {
 		return err
 	}
-	d.e.open(url)
+	if d.tracer != nil {
+		_ = d.tracer.Close()
+	}
+	d.tracer = t
 	return nil
 }
 
+func (d *Driver) Close() error {
+	err := d.e.Close()
+	if d.tracer != nil {
+		if terr := d.tracer.Close(); err == nil {
+			err = terr
+		}
+	}
+	return err
+}
+
+func (d *Driver) MarkLoggedIn(loggedIn bool) { d.e.MarkLoggedIn(loggedIn) }
+
+func (d *Driver) Open(ctx context.Context, url string) error {
+	start := time.Now()
+	err := ctx.Err()
+	if err == nil {
+		err = d.e.open(url)
+	}
+	d.tracer.Record(TraceEntry{Op: TraceOpOpen, URL: url}, start, err)
+	return err
+}
+
 func (d *Driver) Screenshot(ctx context.Context, filePath string) error {
-	if err := ctx.Err(); err != nil {
-		return err
+	start := time.Now()
+	err := ctx.Err()
+	if err == nil {
+		err = d.e.screenshot(filePath)
 	}
-	return d.e.screenshot(filePath)
+	d.tracer.RecordScreenshot(TraceEntry{Op: TraceOpScreenshot}, filePath, start, err)
+	return err
 }
 
 func (d *Driver) WaitAnyVisible(ctx context.Context, selectors []string) error {
-	_, _, err := d.e.findFirstVisible(ctx, selectors, d.e.cfg.StepTimeout)
+	start := time.Now()
+	_, matched, err := d.e.findFirstVisible(ctx, selectors, d.e.cfg.StepTimeout)
+	d.tracer.Record(TraceEntry{Op: TraceOpWaitAnyVisible, Selectors: selectors, Matched: matched}, start, err)
 	return err
 }
 
 func (d *Driver) IsVisible(ctx context.Context, selectors []string) (bool, error) {
+	start := time.Now()
+	matched, err := d.isVisible(ctx, selectors)
+	visible := matched != ""
+	d.tracer.Record(TraceEntry{Op: TraceOpIsVisible, Selectors: selectors, Matched: matched, Visible: &visible}, start, err)
+	return visible, err
+}
+
+// isVisible returns the first selector whose element is visible right now.
+func (d *Driver) isVisible(ctx context.Context, selectors []string) (string, error) {
 	if err := ctx.Err(); err != nil {
-		return false, err
+		return "", err
+	}
+	if err := d.e.RequirePage(); err != nil {
+		return "", err
 	}
-	d.e.MustHavePage()
 
 	for _, sel := range selectors {
 		el, _ := d.e.page.Timeout(600 * time.Millisecond).Element(sel)
@@ -53,33 +97,84 @@ func (d *Driver) IsVisible(ctx context.Context, selectors []string) (bool, error
 			return !!(r && r.width > 0 && r.height > 0);
 		}`)
 		if ok {
-			return true, nil
+			return sel, nil
 		}
 	}
 
-	return false, nil
+	return "", nil
 }
 
 func (d *Driver) ClickBySelectors(ctx context.Context, selectors []string) error {
-	return d.e.retry(ctx, func() error {
-		el, _, err := d.e.findFirstVisible(ctx, selectors, d.e.cfg.StepTimeout)
+	start := time.Now()
+	var matched string
+	err := d.e.retry(ctx, TraceOpClick, func() error {
+		el, sel, err := d.e.findFirstVisible(ctx, selectors, d.e.cfg.StepTimeout)
+		if err != nil {
+			return err
+		}
+		if el == nil {
+			return fmt.Errorf("%w: %v", ErrElementNotFound, selectors)
+		}
+		matched = sel
+		return d.e.click(ctx, el)
+	})
+	d.tracer.Record(TraceEntry{Op: TraceOpClick, Selectors: selectors, Matched: matched}, start, err)
+	return err
+}
+
+func (d *Driver) TypeText(ctx context.Context, selectors []string, text string) error {
+	start := time.Now()
+	matched, err := d.typeInto(ctx, TraceOpTypeText, selectors, text)
+	d.tracer.Record(TraceEntry{Op: TraceOpTypeText, Selectors: selectors, Matched: matched, Input: text}, start, err)
+	return err
+}
+
+// TypeSecret is TypeText for credentials and login codes: the trace leaves
+// the text out.
+func (d *Driver) TypeSecret(ctx context.Context, selectors []string, text string) error {
+	start := time.Now()
+	matched, err := d.typeInto(ctx, TraceOpTypeSecret, selectors, text)
+	d.tracer.Record(TraceEntry{Op: TraceOpTypeSecret, Selectors: selectors, Matched: matched}, start, err)
+	return err
+}
+
+func (d *Driver) typeInto(ctx context.Context, op TraceOp, selectors []string, text string) (matched string, err error) {
+	err = d.e.retry(ctx, op, func() error {
+		el, sel, err := d.e.findFirstVisible(ctx, selectors, d.e.cfg.StepTimeout)
 		if err != nil {
 			return err
 		}
 		if el == nil {
-			return fmt.Errorf("element not found for selectors: %v", selectors)
+			return fmt.Errorf("%w: %v", ErrElementNotFound, selectors)
 		}
-		_ = el.ScrollIntoView()
-		el.MustClick()
-		return nil
+		matched = sel
+		return d.e.click(ctx, el)
 	})
+	if err == nil {
+		// Not retried: a partial write would be typed twice.
+		err = d.e.typeText(ctx, text)
+	}
+	return matched, err
 }
 
 func (d *Driver) ScreenshotElement(ctx context.Context, selector string, filePath string) error {
+	start := time.Now()
+	err := d.screenshotElement(ctx, selector, filePath)
+	entry := TraceEntry{Op: TraceOpScreenshotElement, Selectors: []string{selector}}
+	if err == nil {
+		entry.Matched = selector
+	}
+	d.tracer.RecordScreenshot(entry, filePath, start, err)
+	return err
+}
+
+func (d *Driver) screenshotElement(ctx context.Context, selector string, filePath string) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
-	d.e.MustHavePage()
+	if err := d.e.RequirePage(); err != nil {
+		return err
+	}
 
 	if err := ensureDir(filePath); err != nil {
 		return err
@@ -90,10 +185,12 @@ func (d *Driver) ScreenshotElement(ctx context.Context, selector string, filePat
 		return err
 	}
 	if el == nil {
-		return fmt.Errorf("element not found for selector: %s", selector)
+		return fmt.Errorf("%w: %s", ErrElementNotFound, selector)
 	}
 
-	_ = el.ScrollIntoView()
+	if err := el.ScrollIntoView(); err != nil {
+		return err
+	}
 
 	// ✅ element screenshot API: (format, quality)
 	// quality is used for JPEG; for PNG it’s ignored but still required.
@@ -105,6 +202,79 @@ func (d *Driver) ScreenshotElement(ctx context.Context, selector string, filePat
 	return os.WriteFile(filePath, buf, 0o644)
 }
 
+func (d *Driver) ScreenshotSections(ctx context.Context, selector string, pathFor func(i int) string) ([]string, error) {
+	start := time.Now()
+	paths, err := d.screenshotSections(ctx, selector, pathFor)
+	d.tracer.RecordScreenshots(TraceEntry{Op: TraceOpScreenshotSections, Selectors: []string{selector}}, paths, start, err)
+	return paths, err
+}
+
+func (d *Driver) screenshotSections(ctx context.Context, selector string, pathFor func(i int) string) ([]string, error) {
+	els, err := d.elements(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(els) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrElementNotFound, selector)
+	}
+
+	paths := make([]string, 0, len(els))
+	for i, el := range els {
+		if err := el.ScrollIntoView(); err != nil {
+			return paths, err
+		}
+		// let lazy images and sticky headers settle after the scroll
+		if d.e.cfg.Human.Enabled {
+			if err := sleepCtx(ctx, d.e.cfg.Human.ScrollSettle); err != nil {
+				return paths, err
+			}
+		}
+		buf, err := el.Screenshot(proto.PageCaptureScreenshotFormatPng, 100)
+		if err != nil {
+			return paths, err
+		}
+		path := pathFor(i + 1)
+		if err := ensureDir(path); err != nil {
+			return paths, err
+		}
+		if err := os.WriteFile(path, buf, 0o644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func (d *Driver) WatchResponses(ctx context.Context, patterns []string, fn func(NetworkResponse)) (func(), error) {
+	start := time.Now()
+	stop, err := d.watchResponses(ctx, patterns, fn)
+	d.tracer.Record(TraceEntry{Op: TraceOpWatchResponses, Selectors: patterns}, start, err)
+	return stop, err
+}
+
+func (d *Driver) watchResponses(ctx context.Context, patterns []string, fn func(NetworkResponse)) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	watch := d.watches + 1
+	stop, err := d.e.watchResponses(patterns, func(r NetworkResponse) {
+		d.tracer.Record(TraceEntry{
+			Op:       TraceOpResponse,
+			URL:      r.URL,
+			Watch:    watch,
+			Status:   r.Status,
+			MIMEType: r.MIMEType,
+			Body:     string(r.Body),
+		}, time.Now(), nil)
+		fn(r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.watches = watch
+	return stop, nil
+}
+
 // compile-time check (optional)
 var _ IDriver = (*Driver)(nil)
 
@@ -0,0 +1,292 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/internal/retry"
+)
+
+func newTestDriver(t *testing.T, fb *fakebrowser.Browser) (*engine.Driver, *engine.Engine) {
+	t.Helper()
+
+	cfg := engine.DefaultConfig()
+	cfg.StepTimeout = 300 * time.Millisecond
+	cfg.RetryAttempts = 2
+	cfg.RetryDelay = time.Millisecond
+	cfg.Human = engine.HumanConfig{Enabled: true, MinMoveSteps: 4, MaxMoveSteps: 8, CurveSpread: 0.25, ClickInset: 0.2, ScrollJitter: 10}
+
+	eng := engine.NewWithBrowser(cfg, fb)
+	drv := engine.NewDriver(eng)
+	if err := drv.Open(context.Background(), "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	return drv, eng
+}
+
+func TestFindFirstVisibleSkipsHiddenAndMissing(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Show("#hidden", "#visible")
+	fb.Hide("#hidden")
+	_, eng := newTestDriver(t, fb)
+
+	el, sel, err := eng.FindFirstVisible(context.Background(), []string{"#missing", "#hidden", "#visible"}, time.Second)
+	if err != nil {
+		t.Fatalf("FindFirstVisible returned error: %v", err)
+	}
+	if el == nil || sel != "#visible" {
+		t.Fatalf("expected #visible to match, got %q", sel)
+	}
+}
+
+func TestFindFirstVisibleTimesOut(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Show("#hidden")
+	fb.Hide("#hidden")
+	_, eng := newTestDriver(t, fb)
+
+	_, _, err := eng.FindFirstVisible(context.Background(), []string{"#hidden"}, 150*time.Millisecond)
+	if !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected ErrElementNotFound, got %v", err)
+	}
+}
+
+func TestFindFirstVisibleRequiresOpenPage(t *testing.T) {
+	t.Parallel()
+
+	eng := engine.NewWithBrowser(engine.DefaultConfig(), fakebrowser.New())
+	_, _, err := eng.FindFirstVisible(context.Background(), []string{"#any"}, time.Second)
+	if !errors.Is(err, engine.ErrPageNotOpen) {
+		t.Fatalf("expected ErrPageNotOpen, got %v", err)
+	}
+}
+
+func TestClickBySelectorsUsesFirstVisibleAndRunsSideEffect(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Set("#primary", fakebrowser.Node{Visible: false})
+	fb.Set("#fallback", fakebrowser.Node{
+		Visible: true,
+		OnClick: func(b *fakebrowser.Browser) { b.Show("#after-click") },
+	})
+	drv, _ := newTestDriver(t, fb)
+
+	if err := drv.ClickBySelectors(context.Background(), []string{"#primary", "#fallback"}); err != nil {
+		t.Fatalf("ClickBySelectors returned error: %v", err)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{"#fallback"}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+
+	visible, err := drv.IsVisible(context.Background(), []string{"#after-click"})
+	if err != nil {
+		t.Fatalf("IsVisible returned error: %v", err)
+	}
+	if !visible {
+		t.Fatalf("expected click side effect to show #after-click")
+	}
+}
+
+func TestClickBySelectorsDisabledNodeSkipsSideEffect(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fired := false
+	fb.Set("#btn", fakebrowser.Node{
+		Visible:  true,
+		Disabled: true,
+		OnClick:  func(*fakebrowser.Browser) { fired = true },
+	})
+	drv, _ := newTestDriver(t, fb)
+
+	if err := drv.ClickBySelectors(context.Background(), []string{"#btn"}); err != nil {
+		t.Fatalf("ClickBySelectors returned error: %v", err)
+	}
+	if fired {
+		t.Fatalf("disabled node should not run its click side effect")
+	}
+}
+
+func TestClickBySelectorsReportsNotFound(t *testing.T) {
+	t.Parallel()
+
+	drv, _ := newTestDriver(t, fakebrowser.New())
+
+	err := drv.ClickBySelectors(context.Background(), []string{"#nope"})
+	if !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected ErrElementNotFound, got %v", err)
+	}
+}
+
+func TestClickBySelectorsReportsDetached(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Set("#btn", fakebrowser.Node{
+		Visible:  true,
+		ClickErr: engine.ErrElementDetached,
+	})
+	drv, _ := newTestDriver(t, fb)
+
+	err := drv.ClickBySelectors(context.Background(), []string{"#btn"})
+	if !errors.Is(err, engine.ErrElementDetached) {
+		t.Fatalf("expected ErrElementDetached, got %v", err)
+	}
+	if !engine.IsRecoverable(err) {
+		t.Fatalf("detached element should be recoverable")
+	}
+}
+
+func TestClickBySelectorsRetriesOnlyTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Set("#btn", fakebrowser.Node{Visible: true, ClickErr: engine.ErrElementDetached})
+	cfg := engine.DefaultConfig()
+	cfg.StepTimeout = 100 * time.Millisecond
+	cfg.RetryAttempts = 3
+	cfg.RetryDelay = time.Millisecond
+	var attempts []retry.Attempt
+	cfg.RetryObserver = func(a retry.Attempt) { attempts = append(attempts, a) }
+	drv := engine.NewDriver(engine.NewWithBrowser(cfg, fb))
+	ctx := context.Background()
+	if err := drv.Open(ctx, "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	if err := drv.ClickBySelectors(ctx, []string{"#btn"}); !errors.Is(err, engine.ErrElementDetached) {
+		t.Fatalf("expected ErrElementDetached, got %v", err)
+	}
+	if len(attempts) != 3 || attempts[2].Class != retry.Transient || attempts[0].Op != "click_by_selectors" {
+		t.Fatalf("detached click attempts = %+v, want 3 transient click_by_selectors tries", attempts)
+	}
+
+	attempts = nil
+	if err := drv.ClickBySelectors(ctx, []string{"#nope"}); !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected ErrElementNotFound, got %v", err)
+	}
+	if len(attempts) != 1 || attempts[0].Class != retry.Fatal {
+		t.Fatalf("missing selector attempts = %+v, want one fatal try", attempts)
+	}
+}
+
+func TestZeroConfigRetryPolicyIsBounded(t *testing.T) {
+	t.Parallel()
+
+	p := engine.NewWithBrowser(engine.Config{}, fakebrowser.New()).RetryPolicy()
+	if p.Attempts <= 0 || p.BaseDelay <= 0 || p.MaxDelay <= 0 || p.Jitter <= 0 || p.Budget <= 0 {
+		t.Fatalf("zero Config retry policy = %+v, want every bound defaulted", p)
+	}
+	def := engine.DefaultConfig()
+	if p.MaxDelay != def.RetryMaxDelay || p.Jitter != def.RetryJitter || p.Budget != def.RetryBudget {
+		t.Fatalf("zero Config retry policy = %+v, want DefaultConfig's cap, jitter and budget", p)
+	}
+}
+
+func TestScreenshotElementWritesNodeBytes(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Set("#album", fakebrowser.Node{Visible: true, Screenshot: []byte("png-bytes")})
+	drv, _ := newTestDriver(t, fb)
+
+	path := filepath.Join(t.TempDir(), "nested", "shot.png")
+	if err := drv.ScreenshotElement(context.Background(), "#album", path); err != nil {
+		t.Fatalf("ScreenshotElement returned error: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read screenshot: %v", err)
+	}
+	if string(got) != "png-bytes" {
+		t.Fatalf("unexpected screenshot bytes: %q", got)
+	}
+}
+
+func TestOpenClosesPreviousTab(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	drv := engine.NewDriver(engine.NewWithBrowser(engine.DefaultConfig(), fb))
+	for range 3 {
+		if err := drv.Open(context.Background(), "https://example.test/app"); err != nil {
+			t.Fatalf("Open returned error: %v", err)
+		}
+	}
+	if got := fb.Tabs(); got != 1 {
+		t.Fatalf("open tabs = %d after three opens, want 1", got)
+	}
+}
+
+func TestOpenMapsLoadTimeout(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.FailLoads(context.DeadlineExceeded)
+	drv := engine.NewDriver(engine.NewWithBrowser(engine.DefaultConfig(), fb))
+
+	err := drv.Open(context.Background(), "https://example.test/app")
+	if !errors.Is(err, engine.ErrNavigationTimeout) {
+		t.Fatalf("expected ErrNavigationTimeout, got %v", err)
+	}
+	if !engine.IsRecoverable(err) {
+		t.Fatalf("navigation timeout should be recoverable")
+	}
+	if engine.IsRecoverable(fmt.Errorf("%w: gone", engine.ErrConnectionLost)) {
+		t.Fatalf("lost connection should not be recoverable")
+	}
+}
+
+func TestClickBySelectorsDoesNotClickCoveringOverlay(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Set("#btn", fakebrowser.Node{Visible: true, Box: &proto.DOMRect{X: 100, Y: 300, Width: 80, Height: 40}})
+	fb.Set("#toast", fakebrowser.Node{Visible: true, Box: &proto.DOMRect{X: 0, Y: 250, Width: 400, Height: 120}})
+	drv, _ := newTestDriver(t, fb)
+
+	if err := drv.ClickBySelectors(context.Background(), []string{"#btn"}); err != nil {
+		t.Fatalf("ClickBySelectors returned error: %v", err)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{"#btn"}) {
+		t.Fatalf("clicks = %v, want the covered #btn clicked directly, not #toast", got)
+	}
+}
+
+func TestClickBySelectorsMovesPointerInsideBox(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Set("#btn", fakebrowser.Node{Visible: true, Box: &proto.DOMRect{X: 100, Y: 300, Width: 80, Height: 40}})
+	drv, _ := newTestDriver(t, fb)
+
+	if err := drv.ClickBySelectors(context.Background(), []string{"#btn"}); err != nil {
+		t.Fatalf("ClickBySelectors returned error: %v", err)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{"#btn"}) {
+		t.Fatalf("expected pointer click to hit #btn, got %v", got)
+	}
+
+	pt := fb.Pointer()
+	// ClickInset 0.2 keeps the point inside the inner 60% of the box.
+	if pt.X < 116 || pt.X > 164 || pt.Y < 308 || pt.Y > 332 {
+		t.Fatalf("click point %v outside inset box", pt)
+	}
+	if w := fb.Wheel(); len(w) != 1 || w[0] < -10 || w[0] > 10 {
+		t.Fatalf("expected one scroll jitter within ±10px, got %v", w)
+	}
+}
@@ -1,30 +1,64 @@
 package engine
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/go-rod/rod"
-	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
+	"github.com/vd09-projects/swipeassist/internal/retry"
 )
 
 type Config struct {
 	Headless   bool
 	ControlURL string
 
-	StepTimeout   time.Duration
+	StepTimeout       time.Duration
+	NavigationTimeout time.Duration
+	// Retries of clicks and typing (see retry.Policy): RetryDelay doubles
+	// after each try up to RetryMaxDelay, randomised by RetryJitter, and no
+	// retry starts after RetryBudget. Missing elements are not retried.
 	RetryAttempts int
 	RetryDelay    time.Duration
+	RetryMaxDelay time.Duration
+	RetryJitter   float64
+	RetryBudget   time.Duration
+	// RetryObserver, when set, sees every attempt (for metrics).
+	RetryObserver retry.Observer
+
+	// SettleTimeout bounds one WaitFor call, all its conditions together.
+	SettleTimeout time.Duration
+
+	// Human shapes how clicks are delivered (pointer path, dwell, scroll jitter).
+	Human HumanConfig
+
+	// SessionFile keeps the app's login across launched browsers: the first
+	// Open restores the cookies and storage saved there, Close saves them
+	// back once MarkLoggedIn confirmed the page (see Session). The file is
+	// plaintext JSON holding live session cookies, written with mode 0600;
+	// treat it like a password. Empty disables it.
+	SessionFile string
+
+	// Profile sets up launched browsers and the device every page emulates
+	// (see LoadProfile). The zero value keeps the browser's defaults.
+	Profile Profile
 }
 
 func DefaultConfig() Config {
 	return Config{
-		Headless:      false,
-		StepTimeout:   6 * time.Second,
-		RetryAttempts: 3,
-		RetryDelay:    250 * time.Millisecond,
+		Headless:          false,
+		StepTimeout:       6 * time.Second,
+		NavigationTimeout: 30 * time.Second,
+		RetryAttempts:     3,
+		RetryDelay:        250 * time.Millisecond,
+		RetryMaxDelay:     2 * time.Second,
+		RetryJitter:       0.2,
+		RetryBudget:       20 * time.Second,
+		SettleTimeout:     10 * time.Second,
+		Human:             DefaultHumanConfig(),
 	}
 }
 
@@ -33,58 +67,250 @@ type Engine struct {
 	page        Page
 	ownsBrowser bool
 	cfg         Config
+	human       *humanizer
+	net         responseWatchers
+	dial        dialFunc // nil when the browser was handed in (NewWithBrowser)
+
+	url           string // last URL opened
+	sessionLoaded bool   // Config.SessionFile was restored by a successful open
+	loggedIn      bool   // the open page was confirmed logged in (MarkLoggedIn)
 }
 
+// dialFunc connects to a browser; owns is true when it launched one.
+type dialFunc func() (b Browser, owns bool, err error)
+
 func New(cfg Config) (*Engine, error) {
-	if cfg.StepTimeout == 0 {
-		cfg.StepTimeout = 6 * time.Second
-	}
-	if cfg.RetryAttempts <= 0 {
-		cfg.RetryAttempts = 3
+	cfg = withDefaults(cfg)
+	if cfg.ControlURL != "" && cfg.Profile.launches() {
+		return nil, fmt.Errorf("profile %s: user_data_dir and proxy only apply to a launched browser, not to %s", cfg.Profile.Name, cfg.ControlURL)
 	}
-	if cfg.RetryDelay <= 0 {
-		cfg.RetryDelay = 250 * time.Millisecond
+	return newEngine(cfg, func() (Browser, bool, error) { return connect(cfg) })
+}
+
+// NewWithDialer connects through dial, and again on every Reconnect. The
+// engine does not own the browsers dial returns.
+func NewWithDialer(cfg Config, dial func() (Browser, error)) (*Engine, error) {
+	cfg = withDefaults(cfg)
+	return newEngine(cfg, func() (Browser, bool, error) {
+		b, err := dial()
+		return b, false, err
+	})
+}
+
+func newEngine(cfg Config, dial dialFunc) (*Engine, error) {
+	b, owns, err := dial()
+	if err != nil {
+		return nil, err
 	}
+	return &Engine{
+		browser:     b,
+		ownsBrowser: owns,
+		cfg:         cfg,
+		human:       newHumanizer(cfg.Human),
+		dial:        dial,
+	}, nil
+}
 
+// connect attaches to cfg.ControlURL (see ResolveControlURL), or launches a
+// browser when it is empty.
+func connect(cfg Config) (Browser, bool, error) {
 	var (
 		url  string
 		owns bool
 	)
 	if cfg.ControlURL != "" {
-		url = cfg.ControlURL
+		resolved, err := ResolveControlURL(context.Background(), cfg.ControlURL)
+		if err != nil {
+			return nil, false, err
+		}
+		url = resolved
 	} else {
-		url = launcher.New().Headless(cfg.Headless).MustLaunch()
+		launched, err := cfg.Profile.launcher(cfg.Headless).Launch()
+		if err != nil {
+			return nil, false, wrapConnect(err)
+		}
+		url = launched
 		owns = true
 	}
 
-	b := rod.New().ControlURL(url).MustConnect()
+	b := rod.New().ControlURL(url)
+	if err := b.Connect(); err != nil {
+		return nil, false, wrapConnect(err)
+	}
+	return RodBrowser{Inner: b}, owns, nil
+}
+
+// NewWithBrowser wraps an already connected Browser (e.g. an in-memory fake in
+// tests). The engine does not own it, so Close leaves it running.
+func NewWithBrowser(cfg Config, b Browser) *Engine {
+	cfg = withDefaults(cfg)
 	return &Engine{
-		browser:     RodBrowser{Inner: b},
-		ownsBrowser: owns,
-		cfg:         cfg,
-	}, nil
+		browser: b,
+		cfg:     cfg,
+		human:   newHumanizer(cfg.Human),
+	}
 }
 
-func (e *Engine) Close() {
+func withDefaults(cfg Config) Config {
+	if cfg.StepTimeout == 0 {
+		cfg.StepTimeout = 6 * time.Second
+	}
+	if cfg.NavigationTimeout <= 0 {
+		cfg.NavigationTimeout = 30 * time.Second
+	}
+	if cfg.RetryAttempts <= 0 {
+		cfg.RetryAttempts = 3
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = 250 * time.Millisecond
+	}
+	if cfg.RetryMaxDelay <= 0 {
+		cfg.RetryMaxDelay = 2 * time.Second
+	}
+	if cfg.RetryJitter <= 0 {
+		cfg.RetryJitter = 0.2
+	}
+	if cfg.RetryBudget <= 0 {
+		cfg.RetryBudget = 20 * time.Second
+	}
+	if cfg.SettleTimeout <= 0 {
+		cfg.SettleTimeout = 10 * time.Second
+	}
+	return cfg
+}
+
+// Close saves the session to Config.SessionFile when the open page was
+// confirmed logged in, so a logged-out or error screen never replaces a good
+// file. It then closes the browser if the engine launched it.
+func (e *Engine) Close() error {
+	var err error
+	if e.cfg.SessionFile != "" && e.page != nil && e.loggedIn {
+		if err = e.SaveSession(e.cfg.SessionFile); err != nil {
+			err = fmt.Errorf("save session: %w", err)
+		}
+	}
+	e.net.detach()
 	if e.browser != nil && e.ownsBrowser {
-		e.browser.MustClose()
+		if cerr := e.browser.Close(); err == nil {
+			err = cerr
+		}
 	}
+	return err
 }
 
-func (e *Engine) MustHavePage() {
+// RequirePage returns ErrPageNotOpen until Open has succeeded.
+func (e *Engine) RequirePage() error {
 	if e.page == nil {
-		panic("engine: page not initialized (call Open first)")
+		return ErrPageNotOpen
+	}
+	return nil
+}
+
+// MarkLoggedIn records whether the open page shows the app logged in; each
+// open clears it.
+func (e *Engine) MarkLoggedIn(loggedIn bool) { e.loggedIn = loggedIn }
+
+func (e *Engine) open(url string) error {
+	sess, err := e.pendingSession()
+	if err != nil {
+		return err
+	}
+	e.loggedIn = false
+	if err := e.openPage(url, sess); err != nil {
+		return err
+	}
+	e.sessionLoaded = true
+	return nil
+}
+
+func (e *Engine) openPage(url string, sess *Session) error {
+	e.url = url
+	device := e.cfg.Profile.Device
+
+	// Start on a blank page so request tracking and watches see the initial
+	// load's requests, and saved cookies and the emulated device are in
+	// place before it.
+	p, err := e.browser.Page(blankURL)
+	if err != nil {
+		return wrapNavigation(url, err)
+	}
+	e.setPage(p)
+	if err := p.TrackRequests(); err != nil {
+		return fmt.Errorf("track requests: %w", err)
+	}
+	if !device.empty() {
+		if err := p.Emulate(device); err != nil {
+			return fmt.Errorf("emulate profile %s: %w", e.cfg.Profile.Name, err)
+		}
+	}
+	if e.net.active() {
+		if err := e.net.attach(p); err != nil {
+			return err
+		}
 	}
+	if sess != nil && len(sess.Cookies) > 0 {
+		if err := p.SetCookies(sess.Cookies); err != nil {
+			return fmt.Errorf("restore session cookies: %w", err)
+		}
+	}
+	if err := e.navigate(url); err != nil {
+		return err
+	}
+	if sess == nil || sess.empty() || originOf(url) != sess.Origin {
+		return nil
+	}
+	if err := e.page.SetStorage(sess.OriginStorage); err != nil {
+		return fmt.Errorf("restore session storage: %w", err)
+	}
+	// The app read its storage while loading; load it again to pick up the
+	// restored values.
+	return e.navigate(url)
 }
 
-func (e *Engine) open(url string) {
-	p := e.browser.MustPage(url)
+// setPage makes p the open page and closes the tab it replaces, so reopens
+// don't pile up tabs.
+func (e *Engine) setPage(p Page) {
+	if e.page != nil {
+		e.net.detach()
+		_ = e.page.Close()
+	}
 	e.page = p
-	_ = e.page.MustWaitLoad()
+}
+
+func (e *Engine) navigate(url string) error {
+	if err := e.page.Navigate(url); err != nil {
+		return wrapNavigation(url, err)
+	}
+	return wrapNavigation(url, e.page.Timeout(e.cfg.NavigationTimeout).WaitLoad())
+}
+
+const blankURL = "about:blank"
+
+// click scrolls el into view and clicks it, through the humanizer when
+// enabled.
+func (e *Engine) click(ctx context.Context, el Element) error {
+	if err := el.ScrollIntoView(); err != nil {
+		return err
+	}
+	if !e.cfg.Human.Enabled {
+		return el.Click()
+	}
+	return e.human.click(ctx, e.page, el)
+}
+
+// typeText types into the focused element, key by key through the humanizer
+// when enabled.
+func (e *Engine) typeText(ctx context.Context, text string) error {
+	if !e.cfg.Human.Enabled {
+		return e.page.InsertText(text)
+	}
+	return e.human.typeText(ctx, e.page, text)
 }
 
 func (e *Engine) screenshot(filePath string) error {
-	e.MustHavePage()
+	if err := e.RequirePage(); err != nil {
+		return err
+	}
 	if err := ensureDir(filePath); err != nil {
 		return err
 	}
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+)
+
+// Typed engine failures. Callers match them with errors.Is; the underlying
+// rod/cdp error stays wrapped for logging.
+var (
+	ErrConnectionFailed  = errors.New("engine: browser connection failed")
+	ErrConnectionLost    = errors.New("engine: browser connection lost")
+	ErrNavigationTimeout = errors.New("engine: navigation timeout")
+	ErrElementDetached   = errors.New("engine: element detached")
+	ErrElementNotFound   = errors.New("engine: element not found")
+	ErrPageNotOpen       = errors.New("engine: page not open (call Open first)")
+	ErrWaitTimeout       = errors.New("engine: wait condition not met")
+)
+
+// IsRecoverable reports whether err is a page-level hiccup (slow navigation,
+// element re-rendered under us) that a caller can retry or skip past, as
+// opposed to a broken browser session.
+func IsRecoverable(err error) bool {
+	return errors.Is(err, ErrNavigationTimeout) || errors.Is(err, ErrElementDetached)
+}
+
+func wrapConnect(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+}
+
+func wrapNavigation(url string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %s: %w", ErrNavigationTimeout, url, err)
+	}
+	return fmt.Errorf("open %s: %w", url, err)
+}
+
+// wrapElement maps rod/cdp errors that mean "the node we held is gone or no
+// longer interactable" onto ErrElementDetached.
+func wrapElement(err error) error {
+	if err == nil {
+		return nil
+	}
+	if isDetached(err) {
+		return fmt.Errorf("%w: %w", ErrElementDetached, err)
+	}
+	return err
+}
+
+func isDetached(err error) bool {
+	var (
+		objErr   *rod.ObjectNotFoundError
+		shapeErr *rod.InvisibleShapeError
+		interErr *rod.NotInteractableError
+		cdpErr   *cdp.Error
+	)
+	switch {
+	case errors.As(err, &objErr), errors.As(err, &shapeErr), errors.As(err, &interErr):
+		return true
+	case errors.As(err, &cdpErr):
+		msg := strings.ToLower(cdpErr.Message)
+		return cdpErr.Message == cdp.ErrObjNotFound.Message ||
+			cdpErr.Message == cdp.ErrNodeNotFoundAtPos.Message ||
+			strings.Contains(msg, "detached") ||
+			strings.Contains(msg, "no node with given id")
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/internal/retry"
+)
+
+// FindFirstVisible exposes findFirstVisible to the engine_test package.
+func (e *Engine) FindFirstVisible(ctx context.Context, selectors []string, timeout time.Duration) (Element, string, error) {
+	return e.findFirstVisible(ctx, selectors, timeout)
+}
+
+// RetryPolicy exposes the policy clicks and typing are retried under.
+func (e *Engine) RetryPolicy() retry.Policy { return e.retryPolicy() }
@@ -0,0 +1,810 @@
+// Package fakebrowser is an in-memory engine.Browser for offline tests.
+//
+// A Browser holds a scripted DOM: a set of selectors, each mapped to a Node
+// with visibility, a disabled flag, screenshot bytes and an optional click
+// side effect. Selectors are matched literally (no CSS parsing), which is
+// enough to drive the engine's "first visible selector wins" logic. Nodes
+// without an explicit Box are laid out as a vertical stack so pointer clicks
+// can be hit-tested.
+package fakebrowser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/vd09-projects/swipeassist/apps/engine"
+)
+
+// Node is the scripted state behind one selector.
+type Node struct {
+	Visible bool
+	// Disabled nodes record clicks but skip OnClick, like a disabled control.
+	Disabled   bool
+	Screenshot []byte
+	// OnClick runs after a successful click; use it to move the DOM to the
+	// next state (swap album photo, advance to the next card, ...).
+	OnClick func(b *Browser)
+	// ClickErr is returned from Click instead of clicking.
+	ClickErr error
+	// Box is the viewport rectangle; nil gets an auto-layout slot.
+	Box *proto.DOMRect
+
+	Text  string
+	Attrs map[string]string
+	// Children maps a descendant selector to the nodes it matches.
+	Children map[string][]Node
+
+	// ImagesLoading makes ImagesDecoded report false.
+	ImagesLoading bool
+
+	changed time.Time // last Set, Show, Hide or Move; see QuietFor
+}
+
+const (
+	slotHeight = 40
+	slotGap    = 10
+	slotWidth  = 200
+)
+
+// Browser implements engine.Browser. The zero value is not usable; call New.
+type Browser struct {
+	mu sync.Mutex
+
+	nodes    map[string]*Node
+	lists    map[string][]*Node // SetAll selectors; read-only, never clicked
+	order    []string           // insertion order; later nodes paint on top
+	pageShot []byte
+
+	pointer proto.Point
+	wheel   []float64
+
+	// loadErrs are returned by successive WaitLoad calls (nil once drained).
+	loadErrs []error
+	pageErr  error
+
+	opened []string
+	clicks []string
+	closed bool
+	tabs   int // pages handed out and not closed
+	gen    int // bumped by Disconnect; older pages are dead
+
+	focus string            // last clicked selector
+	typed map[string]string // text typed into each selector
+
+	responders map[int]func(engine.NetworkResponse)
+	nextResp   int
+
+	cookies []engine.Cookie
+	storage engine.OriginStorage
+
+	inflight int // requests WaitRequestIdle waits out
+
+	emulated []Emulation
+}
+
+// Emulation is one Page.Emulate call.
+type Emulation struct {
+	Device engine.Device
+	Opened int // len(Opened()) at the time, to check it came before a load
+}
+
+var _ engine.Browser = (*Browser)(nil)
+
+func New() *Browser {
+	return &Browser{nodes: make(map[string]*Node), lists: make(map[string][]*Node), typed: make(map[string]string)}
+}
+
+// Set replaces the node behind selector.
+func (b *Browser) Set(selector string, n Node) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cp := n
+	b.put(selector, &cp)
+}
+
+// SetAll makes selector match every node in ns, in order, for Elements.
+// Element still only sees nodes added with Set or Show. No nodes clears it.
+func (b *Browser) SetAll(selector string, ns ...Node) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(ns) == 0 {
+		delete(b.lists, selector)
+		return
+	}
+	list := make([]*Node, len(ns))
+	for i := range ns {
+		cp := ns[i]
+		list[i] = &cp
+	}
+	b.lists[selector] = list
+}
+
+func (b *Browser) put(selector string, n *Node) {
+	if _, exists := b.nodes[selector]; !exists {
+		b.order = append(b.order, selector)
+	}
+	if n.Box == nil {
+		slot := float64(len(b.order) - 1)
+		for i, sel := range b.order {
+			if sel == selector {
+				slot = float64(i)
+				break
+			}
+		}
+		n.Box = &proto.DOMRect{X: slotGap, Y: slotGap + slot*(slotHeight+slotGap), Width: slotWidth, Height: slotHeight}
+	}
+	n.changed = time.Now()
+	b.nodes[selector] = n
+}
+
+// Show adds selector as a visible node (or makes an existing one visible).
+func (b *Browser) Show(selectors ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sel := range selectors {
+		if n, ok := b.nodes[sel]; ok {
+			n.Visible = true
+			n.changed = time.Now()
+			continue
+		}
+		b.put(sel, &Node{Visible: true})
+	}
+}
+
+// Hide keeps the nodes attached but gives them an empty bounding box.
+func (b *Browser) Hide(selectors ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sel := range selectors {
+		if n, ok := b.nodes[sel]; ok {
+			n.Visible = false
+			n.changed = time.Now()
+		}
+	}
+}
+
+// Remove detaches the nodes; elements already handed out start failing with
+// engine.ErrElementDetached.
+func (b *Browser) Remove(selectors ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sel := range selectors {
+		delete(b.nodes, sel)
+		for i, o := range b.order {
+			if o == sel {
+				b.order = append(b.order[:i], b.order[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Move gives an existing node a new box, as one step of an animation.
+func (b *Browser) Move(selector string, box proto.DOMRect) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if n, ok := b.nodes[selector]; ok {
+		n.Box = &box
+		n.changed = time.Now()
+	}
+}
+
+// InFlight sets how many requests are loading; WaitRequestIdle waits for
+// zero.
+func (b *Browser) InFlight(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.inflight = n
+}
+
+// SetDisabled toggles the disabled flag of an existing node.
+func (b *Browser) SetDisabled(selector string, disabled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if n, ok := b.nodes[selector]; ok {
+		n.Disabled = disabled
+	}
+}
+
+// OnClick installs a click side effect on an existing node.
+func (b *Browser) OnClick(selector string, fn func(b *Browser)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if n, ok := b.nodes[selector]; ok {
+		n.OnClick = fn
+	}
+}
+
+// SetPageScreenshot sets the bytes returned by full page screenshots.
+func (b *Browser) SetPageScreenshot(buf []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pageShot = buf
+}
+
+// FailPage makes the next Page calls fail with err (nil clears it).
+func (b *Browser) FailPage(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pageErr = err
+}
+
+// FailLoads queues errors for successive WaitLoad calls.
+func (b *Browser) FailLoads(errs ...error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.loadErrs = append(b.loadErrs, errs...)
+}
+
+// Respond delivers a finished XHR/fetch response to every page subscribed
+// through OnResponse.
+func (b *Browser) Respond(r engine.NetworkResponse) {
+	b.mu.Lock()
+	fns := make([]func(engine.NetworkResponse), 0, len(b.responders))
+	for i := 0; i < b.nextResp; i++ {
+		if fn, ok := b.responders[i]; ok {
+			fns = append(fns, fn)
+		}
+	}
+	b.mu.Unlock()
+	for _, fn := range fns {
+		fn(r)
+	}
+}
+
+// RespondJSON is Respond with a 200 application/json body.
+func (b *Browser) RespondJSON(url string, body []byte) {
+	b.Respond(engine.NetworkResponse{URL: url, Status: 200, MIMEType: "application/json", Body: body})
+}
+
+// AddCookies stores cookies as if the site had set them; one with the same
+// name and domain is replaced.
+func (b *Browser) AddCookies(cookies ...engine.Cookie) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addCookies(cookies)
+}
+
+func (b *Browser) addCookies(cookies []engine.Cookie) {
+	for _, c := range cookies {
+		replaced := false
+		for i, have := range b.cookies {
+			if have.Name == c.Name && have.Domain == c.Domain {
+				b.cookies[i] = c
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			b.cookies = append(b.cookies, c)
+		}
+	}
+}
+
+// ClearCookies empties the cookie jar.
+func (b *Browser) ClearCookies() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cookies = nil
+}
+
+// Cookies returns the cookie jar, in the order cookies were first set.
+func (b *Browser) Cookies() []engine.Cookie {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]engine.Cookie(nil), b.cookies...)
+}
+
+// SetStorage replaces the origin storage pages read with Storage.
+func (b *Browser) SetStorage(s engine.OriginStorage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.storage = s
+}
+
+// Storage returns the origin storage as last written.
+func (b *Browser) Storage() engine.OriginStorage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.storage
+}
+
+// Disconnect kills every page handed out so far, as a crash or a closed
+// tab would: their Alive fails. Pages from later Page calls work; the
+// scripted DOM is kept.
+func (b *Browser) Disconnect() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.gen++
+}
+
+// Opened returns the URLs passed to Page or Navigate, in order.
+func (b *Browser) Opened() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.opened...)
+}
+
+// Emulated returns the Emulate calls, in order.
+func (b *Browser) Emulated() []Emulation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Emulation(nil), b.emulated...)
+}
+
+// Tabs returns how many pages are open: handed out by Page and not closed.
+func (b *Browser) Tabs() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tabs
+}
+
+// Clicks returns the selectors that received a click, in order.
+func (b *Browser) Clicks() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.clicks...)
+}
+
+// Typed returns the text typed into selector so far.
+func (b *Browser) Typed(selector string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.typed[selector]
+}
+
+// Pointer returns the last pointer position.
+func (b *Browser) Pointer() proto.Point {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.pointer
+}
+
+// Wheel returns the vertical wheel deltas received, in order.
+func (b *Browser) Wheel() []float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]float64(nil), b.wheel...)
+}
+
+// Closed reports whether Close was called.
+func (b *Browser) Closed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+func (b *Browser) Page(url string) (engine.Page, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if url != "about:blank" { // a blank tab is not a navigation
+		b.opened = append(b.opened, url)
+	}
+	if b.pageErr != nil {
+		return nil, b.pageErr
+	}
+	b.tabs++
+	return &page{b: b, gen: b.gen, tab: new(bool), tracking: new(bool)}, nil
+}
+
+func (b *Browser) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return nil
+}
+
+func (b *Browser) node(selector string) (*Node, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n, ok := b.nodes[selector]
+	return n, ok
+}
+
+type page struct {
+	b        *Browser
+	gen      int
+	tab      *bool     // closed; shared with Timeout copies
+	tracking *bool     // TrackRequests called; shared with Timeout copies
+	deadline time.Time // set by Timeout; only WaitRequestIdle honours it
+}
+
+func (p *page) WaitLoad() error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	if len(p.b.loadErrs) == 0 {
+		return nil
+	}
+	err := p.b.loadErrs[0]
+	p.b.loadErrs = p.b.loadErrs[1:]
+	return err
+}
+
+func (p *page) Navigate(url string) error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	p.b.opened = append(p.b.opened, url)
+	return p.b.pageErr
+}
+
+func (p *page) Timeout(d time.Duration) engine.Page {
+	cp := *p
+	cp.deadline = time.Now().Add(d)
+	return &cp
+}
+
+func (p *page) TrackRequests() error {
+	*p.tracking = true
+	return nil
+}
+
+// WaitRequestIdle polls the InFlight count until it has been zero for quiet.
+// Like the real page it needs TrackRequests first.
+func (p *page) WaitRequestIdle(quiet time.Duration) error {
+	if !*p.tracking {
+		return errors.New("fakebrowser: WaitRequestIdle before TrackRequests")
+	}
+	var idleSince time.Time
+	for {
+		p.b.mu.Lock()
+		busy := p.b.inflight > 0
+		p.b.mu.Unlock()
+		switch {
+		case busy:
+			idleSince = time.Time{}
+		case idleSince.IsZero():
+			idleSince = time.Now()
+		case time.Since(idleSince) >= quiet:
+			return nil
+		}
+		if !p.deadline.IsZero() && time.Now().After(p.deadline) {
+			return context.DeadlineExceeded
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func (p *page) OnResponse(fn func(engine.NetworkResponse)) (func(), error) {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	if p.b.responders == nil {
+		p.b.responders = make(map[int]func(engine.NetworkResponse))
+	}
+	id := p.b.nextResp
+	p.b.nextResp++
+	p.b.responders[id] = fn
+	return func() {
+		p.b.mu.Lock()
+		defer p.b.mu.Unlock()
+		delete(p.b.responders, id)
+	}, nil
+}
+
+func (p *page) Element(selector string) (engine.Element, error) {
+	n, ok := p.b.node(selector)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", engine.ErrElementNotFound, selector)
+	}
+	return &element{b: p.b, selector: selector, node: n}, nil
+}
+
+// Elements returns the SetAll nodes for selector, else the single Set node;
+// no match is an empty slice, as with rod.
+func (p *page) Elements(selector string) ([]engine.Element, error) {
+	p.b.mu.Lock()
+	list, ok := p.b.lists[selector]
+	p.b.mu.Unlock()
+	if ok {
+		out := make([]engine.Element, len(list))
+		for i, n := range list {
+			out[i] = &element{b: p.b, selector: selector, node: n, index: i, inList: true}
+		}
+		return out, nil
+	}
+	n, ok := p.b.node(selector)
+	if !ok {
+		return nil, nil
+	}
+	return []engine.Element{&element{b: p.b, selector: selector, node: n}}, nil
+}
+
+func (p *page) Screenshot(bool, *proto.PageCaptureScreenshot) ([]byte, error) {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	return append([]byte(nil), p.b.pageShot...), nil
+}
+
+func (p *page) Close() error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	if !*p.tab {
+		*p.tab = true
+		p.b.tabs--
+	}
+	return nil
+}
+
+func (p *page) Emulate(d engine.Device) error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	p.b.emulated = append(p.b.emulated, Emulation{Device: d, Opened: len(p.b.opened)})
+	return nil
+}
+
+func (p *page) Alive() error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	if p.gen != p.b.gen {
+		return fmt.Errorf("fakebrowser: target closed")
+	}
+	return nil
+}
+
+func (p *page) MouseMove(to proto.Point) error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	p.b.pointer = to
+	return nil
+}
+
+func (p *page) MouseWheel(_, dy float64) error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	p.b.wheel = append(p.b.wheel, dy)
+	return nil
+}
+
+// InsertText appends text to the last clicked selector (see Typed).
+func (p *page) InsertText(text string) error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	if p.b.focus == "" {
+		return fmt.Errorf("fakebrowser: no focused element to type into")
+	}
+	p.b.typed[p.b.focus] += text
+	return nil
+}
+
+// Cookies returns the whole jar; the fake has no URLs to filter by.
+func (p *page) Cookies() ([]engine.Cookie, error) {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	return append([]engine.Cookie(nil), p.b.cookies...), nil
+}
+
+func (p *page) SetCookies(cookies []engine.Cookie) error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	p.b.addCookies(cookies)
+	return nil
+}
+
+// Storage is the whole browser's storage; the fake has a single origin.
+func (p *page) Storage() (engine.OriginStorage, error) {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	return p.b.storage, nil
+}
+
+// SetStorage sets localStorage items one by one, as the page script does,
+// and replaces IndexedDB wholesale.
+func (p *page) SetStorage(s engine.OriginStorage) error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+	if len(s.LocalStorage) > 0 && p.b.storage.LocalStorage == nil {
+		p.b.storage.LocalStorage = map[string]string{}
+	}
+	for k, v := range s.LocalStorage {
+		p.b.storage.LocalStorage[k] = v
+	}
+	p.b.storage.IndexedDB = append([]engine.IndexedDBStore(nil), s.IndexedDB...)
+	return nil
+}
+
+// MouseClick clicks the topmost visible node under the pointer; a click on
+// empty space is a no-op, as in a real page.
+func (p *page) MouseClick() error {
+	p.b.mu.Lock()
+	hit := p.b.topAt(p.b.pointer)
+	p.b.mu.Unlock()
+	if hit == "" {
+		return nil
+	}
+	el, err := p.Element(hit)
+	if err != nil {
+		return err
+	}
+	return el.Click()
+}
+
+// topAt returns the selector of the topmost visible node at pt; b.mu must
+// be held.
+func (b *Browser) topAt(pt proto.Point) string {
+	for i := len(b.order) - 1; i >= 0; i-- {
+		sel := b.order[i]
+		if n := b.nodes[sel]; n.Visible && contains(n.Box, pt) {
+			return sel
+		}
+	}
+	return ""
+}
+
+func contains(r *proto.DOMRect, pt proto.Point) bool {
+	return r != nil && pt.X >= r.X && pt.X <= r.X+r.Width && pt.Y >= r.Y && pt.Y <= r.Y+r.Height
+}
+
+type element struct {
+	b        *Browser
+	selector string
+	node     *Node
+
+	// Elements found through SetAll or a parent's Children are checked
+	// against their list or parent instead of the selector map.
+	index  int
+	inList bool
+	parent *element
+}
+
+// attached returns the live node, or ErrElementDetached when the selector was
+// removed or replaced since this element was looked up.
+func (e *element) attached() (*Node, error) {
+	detached := fmt.Errorf("%w: %s", engine.ErrElementDetached, e.selector)
+	switch {
+	case e.parent != nil:
+		if _, err := e.parent.attached(); err != nil {
+			return nil, detached
+		}
+		return e.node, nil
+	case e.inList:
+		e.b.mu.Lock()
+		list := e.b.lists[e.selector]
+		e.b.mu.Unlock()
+		if e.index >= len(list) || list[e.index] != e.node {
+			return nil, detached
+		}
+		return e.node, nil
+	}
+	n, ok := e.b.node(e.selector)
+	if !ok || n != e.node {
+		return nil, detached
+	}
+	return n, nil
+}
+
+func (e *element) Text() (string, error) {
+	n, err := e.attached()
+	if err != nil {
+		return "", err
+	}
+	return n.Text, nil
+}
+
+func (e *element) Attribute(name string) (*string, error) {
+	n, err := e.attached()
+	if err != nil {
+		return nil, err
+	}
+	v, ok := n.Attrs[name]
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (e *element) Elements(selector string) ([]engine.Element, error) {
+	n, err := e.attached()
+	if err != nil {
+		return nil, err
+	}
+	kids := n.Children[selector]
+	out := make([]engine.Element, len(kids))
+	for i := range kids {
+		out[i] = &element{b: e.b, selector: selector, node: &kids[i], parent: e}
+	}
+	return out, nil
+}
+
+func (e *element) Click() error {
+	n, err := e.attached()
+	if err != nil {
+		return err
+	}
+
+	e.b.mu.Lock()
+	if n.ClickErr != nil {
+		e.b.mu.Unlock()
+		return n.ClickErr
+	}
+	e.b.clicks = append(e.b.clicks, e.selector)
+	e.b.focus = e.selector
+	fn := n.OnClick
+	disabled := n.Disabled
+	e.b.mu.Unlock()
+
+	if fn != nil && !disabled {
+		fn(e.b)
+	}
+	return nil
+}
+
+func (e *element) ScrollIntoView() error {
+	_, err := e.attached()
+	return err
+}
+
+// EvalBool answers the engine's visibility probe; any script is treated as
+// "does this element have a non-empty bounding box".
+func (e *element) EvalBool(string) (bool, error) {
+	n, err := e.attached()
+	if err != nil {
+		return false, err
+	}
+	e.b.mu.Lock()
+	defer e.b.mu.Unlock()
+	return n.Visible, nil
+}
+
+// QuietFor is the time since the node was last Set, shown, hidden or moved.
+func (e *element) QuietFor() (time.Duration, error) {
+	n, err := e.attached()
+	if err != nil {
+		return 0, err
+	}
+	e.b.mu.Lock()
+	defer e.b.mu.Unlock()
+	return time.Since(n.changed), nil
+}
+
+func (e *element) ImagesDecoded() (bool, error) {
+	n, err := e.attached()
+	if err != nil {
+		return false, err
+	}
+	e.b.mu.Lock()
+	defer e.b.mu.Unlock()
+	return !n.ImagesLoading, nil
+}
+
+func (e *element) Box() (*proto.DOMRect, error) {
+	n, err := e.attached()
+	if err != nil {
+		return nil, err
+	}
+	e.b.mu.Lock()
+	defer e.b.mu.Unlock()
+	if !n.Visible {
+		return &proto.DOMRect{}, nil
+	}
+	box := *n.Box
+	return &box, nil
+}
+
+// HitAt hit-tests pt against the scripted layout. Listed and child nodes
+// have no layout of their own and always report a hit.
+func (e *element) HitAt(pt proto.Point) (bool, error) {
+	n, err := e.attached()
+	if err != nil {
+		return false, err
+	}
+	if e.inList || e.parent != nil {
+		return true, nil
+	}
+	e.b.mu.Lock()
+	defer e.b.mu.Unlock()
+	top := e.b.topAt(pt)
+	return top == e.selector && e.b.nodes[top] == n, nil
+}
+
+func (e *element) Screenshot(proto.PageCaptureScreenshotFormat, int) ([]byte, error) {
+	n, err := e.attached()
+	if err != nil {
+		return nil, err
+	}
+	e.b.mu.Lock()
+	defer e.b.mu.Unlock()
+	return append([]byte(nil), n.Screenshot...), nil
+}
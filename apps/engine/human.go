@@ -0,0 +1,213 @@
+package engine
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+	"unicode"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// HumanConfig tunes the pointer/scroll simulation used for every click.
+// Zero durations mean "no wait", so tests can keep the geometry but skip the
+// pauses.
+type HumanConfig struct {
+	Enabled bool
+
+	// The pointer follows a cubic Bézier curve sampled into this many points.
+	MinMoveSteps int
+	MaxMoveSteps int
+	// Total travel time; per-step delays follow an ease-in-out profile, so the
+	// pointer accelerates away from the start and slows down near the target.
+	MinMoveDuration time.Duration
+	MaxMoveDuration time.Duration
+	// CurveSpread is the max control-point offset as a fraction of the travel
+	// distance (0 = straight line).
+	CurveSpread float64
+
+	// ClickInset is the fraction of each box side kept clear when picking the
+	// click point (0.2 => click lands in the inner 60% of the element).
+	ClickInset float64
+	// Pause over the target before pressing.
+	MinHoverDwell time.Duration
+	MaxHoverDwell time.Duration
+
+	// ScrollJitter is the max wheel nudge in CSS px applied after scrolling an
+	// element into view; ScrollSettle is the pause after the nudge.
+	ScrollJitter float64
+	ScrollSettle time.Duration
+
+	// Typed text goes in one rune at a time with a random gap between keys;
+	// the gap is doubled after a space or punctuation, where people pause.
+	MinKeyDelay time.Duration
+	MaxKeyDelay time.Duration
+}
+
+func DefaultHumanConfig() HumanConfig {
+	return HumanConfig{
+		Enabled:         true,
+		MinMoveSteps:    18,
+		MaxMoveSteps:    40,
+		MinMoveDuration: 180 * time.Millisecond,
+		MaxMoveDuration: 650 * time.Millisecond,
+		CurveSpread:     0.25,
+		ClickInset:      0.2,
+		MinHoverDwell:   60 * time.Millisecond,
+		MaxHoverDwell:   260 * time.Millisecond,
+		ScrollJitter:    24,
+		ScrollSettle:    120 * time.Millisecond,
+		MinKeyDelay:     45 * time.Millisecond,
+		MaxKeyDelay:     190 * time.Millisecond,
+	}
+}
+
+type humanizer struct {
+	cfg HumanConfig
+	rnd *rand.Rand
+	pos proto.Point
+}
+
+func newHumanizer(cfg HumanConfig) *humanizer {
+	return &humanizer{
+		cfg: cfg,
+		rnd: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// click nudges the scroll position, moves the pointer to a random point
+// inside el along a curved path, hovers, then presses. Elements without a
+// usable box, or covered at that point by an overlay, fall back to a plain
+// element click, which waits for them to become interactable.
+func (h *humanizer) click(ctx context.Context, p Page, el Element) error {
+	if h.cfg.ScrollJitter > 0 {
+		dy := (h.rnd.Float64()*2 - 1) * h.cfg.ScrollJitter
+		if err := p.MouseWheel(0, dy); err != nil {
+			return err
+		}
+		if err := sleepCtx(ctx, h.cfg.ScrollSettle); err != nil {
+			return err
+		}
+	}
+
+	box, err := el.Box()
+	if err != nil {
+		return err
+	}
+	if box == nil || box.Width <= 0 || box.Height <= 0 {
+		return el.Click()
+	}
+
+	pt := h.pointIn(box)
+	if err := h.moveTo(ctx, p, pt); err != nil {
+		return err
+	}
+	if err := sleepCtx(ctx, h.between(h.cfg.MinHoverDwell, h.cfg.MaxHoverDwell)); err != nil {
+		return err
+	}
+	hit, err := el.HitAt(pt)
+	if err != nil {
+		return err
+	}
+	if !hit {
+		return el.Click()
+	}
+	return p.MouseClick()
+}
+
+// typeText inserts text into the focused element rune by rune, waiting a
+// random key delay before each one.
+func (h *humanizer) typeText(ctx context.Context, p Page, text string) error {
+	prev := ' '
+	for i, r := range text {
+		if i > 0 {
+			gap := h.between(h.cfg.MinKeyDelay, h.cfg.MaxKeyDelay)
+			if unicode.IsSpace(prev) || unicode.IsPunct(prev) {
+				gap *= 2
+			}
+			if err := sleepCtx(ctx, gap); err != nil {
+				return err
+			}
+		}
+		if err := p.InsertText(string(r)); err != nil {
+			return err
+		}
+		prev = r
+	}
+	return nil
+}
+
+// pointIn picks a point inside the inset box, biased towards the middle
+// (mean of two uniforms) the way real clicks cluster.
+func (h *humanizer) pointIn(box *proto.DOMRect) proto.Point {
+	inset := math.Min(math.Max(h.cfg.ClickInset, 0), 0.45)
+	axis := func(start, size float64) float64 {
+		u := (h.rnd.Float64() + h.rnd.Float64()) / 2
+		return start + size*inset + u*size*(1-2*inset)
+	}
+	return proto.Point{X: axis(box.X, box.Width), Y: axis(box.Y, box.Height)}
+}
+
+func (h *humanizer) moveTo(ctx context.Context, p Page, to proto.Point) error {
+	from := h.pos
+	steps := h.cfg.MinMoveSteps
+	if h.cfg.MaxMoveSteps > steps {
+		steps += h.rnd.Intn(h.cfg.MaxMoveSteps - steps + 1)
+	}
+	if steps < 1 {
+		steps = 1
+	}
+	total := h.between(h.cfg.MinMoveDuration, h.cfg.MaxMoveDuration)
+
+	c1, c2 := h.controlPoints(from, to)
+	prevT := 0.0
+	for i := 1; i <= steps; i++ {
+		t := easeInOut(float64(i) / float64(steps))
+		pt := bezier(from, c1, c2, to, t)
+		if err := p.MouseMove(pt); err != nil {
+			return err
+		}
+		h.pos = pt
+		if err := sleepCtx(ctx, time.Duration(float64(total)*(t-prevT))); err != nil {
+			return err
+		}
+		prevT = t
+	}
+	return nil
+}
+
+// controlPoints offsets the 1/3 and 2/3 points of the straight line
+// perpendicular to it, on random sides, so paths bow and occasionally S-curve.
+func (h *humanizer) controlPoints(from, to proto.Point) (proto.Point, proto.Point) {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	dist := math.Hypot(dx, dy)
+	if dist == 0 || h.cfg.CurveSpread <= 0 {
+		return from, to
+	}
+	nx, ny := -dy/dist, dx/dist
+	off := func() float64 { return (h.rnd.Float64()*2 - 1) * h.cfg.CurveSpread * dist }
+	o1, o2 := off(), off()
+	return proto.Point{X: from.X + dx/3 + nx*o1, Y: from.Y + dy/3 + ny*o1},
+		proto.Point{X: from.X + 2*dx/3 + nx*o2, Y: from.Y + 2*dy/3 + ny*o2}
+}
+
+func (h *humanizer) between(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(h.rnd.Int63n(int64(max-min)+1))
+}
+
+func bezier(p0, p1, p2, p3 proto.Point, t float64) proto.Point {
+	u := 1 - t
+	a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+	return proto.Point{
+		X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+		Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+	}
+}
+
+func easeInOut(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestHumanizerPointInStaysInsideInset(t *testing.T) {
+	t.Parallel()
+
+	h := &humanizer{cfg: HumanConfig{ClickInset: 0.25}, rnd: rand.New(rand.NewSource(1))}
+	box := &proto.DOMRect{X: 10, Y: 20, Width: 100, Height: 40}
+
+	for i := 0; i < 500; i++ {
+		pt := h.pointIn(box)
+		if pt.X < 35 || pt.X > 85 || pt.Y < 30 || pt.Y > 50 {
+			t.Fatalf("point %v outside inset box", pt)
+		}
+	}
+}
+
+func TestBezierHitsEndpoints(t *testing.T) {
+	t.Parallel()
+
+	p0 := proto.Point{X: 0, Y: 0}
+	p3 := proto.Point{X: 300, Y: 120}
+	h := &humanizer{cfg: HumanConfig{CurveSpread: 0.3}, rnd: rand.New(rand.NewSource(7))}
+	c1, c2 := h.controlPoints(p0, p3)
+
+	if got := bezier(p0, c1, c2, p3, 0); got != p0 {
+		t.Fatalf("t=0 should be start, got %v", got)
+	}
+	end := bezier(p0, c1, c2, p3, 1)
+	if math.Abs(end.X-p3.X) > 1e-9 || math.Abs(end.Y-p3.Y) > 1e-9 {
+		t.Fatalf("t=1 should be target, got %v", end)
+	}
+}
+
+func TestEaseInOutIsMonotonic(t *testing.T) {
+	t.Parallel()
+
+	prev := easeInOut(0)
+	for i := 1; i <= 100; i++ {
+		v := easeInOut(float64(i) / 100)
+		if v < prev {
+			t.Fatalf("easeInOut not monotonic at %d: %v < %v", i, v, prev)
+		}
+		prev = v
+	}
+	if prev != 1 {
+		t.Fatalf("easeInOut(1) = %v, want 1", prev)
+	}
+}
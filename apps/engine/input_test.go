@@ -0,0 +1,128 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func TestTypeTextFocusesFirstVisibleAndTypes(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Set("#hidden", fakebrowser.Node{Visible: false})
+	fb.Show("#composer")
+	drv, _ := newTestDriver(t, fb)
+
+	if err := drv.TypeText(context.Background(), []string{"#hidden", "#composer"}, "Hi there"); err != nil {
+		t.Fatalf("TypeText returned error: %v", err)
+	}
+	if got := fb.Typed("#composer"); got != "Hi there" {
+		t.Fatalf("typed %q, want %q", got, "Hi there")
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{"#composer"}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestTypeTextReportsNotFound(t *testing.T) {
+	t.Parallel()
+
+	drv, _ := newTestDriver(t, fakebrowser.New())
+
+	if err := drv.TypeText(context.Background(), []string{"#nope"}, "x"); !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected ErrElementNotFound, got %v", err)
+	}
+}
+
+func TestReplayTypeTextChecksInput(t *testing.T) {
+	t.Parallel()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	fb := fakebrowser.New()
+	fb.Show("#composer")
+	drv, _ := newTestDriver(t, fb)
+	if err := drv.StartTrace(tracePath); err != nil {
+		t.Fatalf("StartTrace returned error: %v", err)
+	}
+	ctx := context.Background()
+	if err := drv.TypeText(ctx, []string{"#composer"}, "hello"); err != nil {
+		t.Fatalf("TypeText returned error: %v", err)
+	}
+	if err := drv.TypeText(ctx, []string{"#composer"}, "again"); err != nil {
+		t.Fatalf("TypeText returned error: %v", err)
+	}
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	r, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	if err := r.TypeText(ctx, []string{"#composer"}, "hello"); err != nil {
+		t.Fatalf("replayed TypeText returned error: %v", err)
+	}
+	if err := r.TypeText(ctx, []string{"#composer"}, "different"); !errors.Is(err, engine.ErrTraceMismatch) {
+		t.Fatalf("expected ErrTraceMismatch, got %v", err)
+	}
+}
+
+func TestTypeSecretStaysOutOfTrace(t *testing.T) {
+	t.Parallel()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	fb := fakebrowser.New()
+	fb.Show("#code")
+	drv, _ := newTestDriver(t, fb)
+	if err := drv.StartTrace(tracePath); err != nil {
+		t.Fatalf("StartTrace returned error: %v", err)
+	}
+	ctx := context.Background()
+	if err := drv.TypeSecret(ctx, []string{"#code"}, "482913"); err != nil {
+		t.Fatalf("TypeSecret returned error: %v", err)
+	}
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if got := fb.Typed("#code"); got != "482913" {
+		t.Fatalf("typed %q", got)
+	}
+	buf, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatalf("read trace: %v", err)
+	}
+	if strings.Contains(string(buf), "482913") {
+		t.Fatalf("trace carries the secret: %s", buf)
+	}
+
+	r, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	if err := r.TypeSecret(ctx, []string{"#code"}, "105377"); err != nil {
+		t.Fatalf("replayed TypeSecret with a new code returned error: %v", err)
+	}
+}
+
+func TestTypeTextKeepsMultibyteRunes(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Show("#composer")
+	drv, _ := newTestDriver(t, fb)
+
+	if err := drv.TypeText(context.Background(), []string{"#composer"}, "Olá, café 👋"); err != nil {
+		t.Fatalf("TypeText returned error: %v", err)
+	}
+	if got := fb.Typed("#composer"); got != "Olá, café 👋" {
+		t.Fatalf("typed %q", got)
+	}
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// NetworkResponse is one completed XHR/fetch response.
+type NetworkResponse struct {
+	URL      string
+	Status   int
+	MIMEType string
+	Body     []byte
+}
+
+// DecodeJSON unmarshals the response body into v.
+func (r NetworkResponse) DecodeJSON(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// urlPattern matches a URL against a glob where '*' is any run of characters
+// (the same syntax as CDP request patterns).
+type urlPattern struct{ re *regexp.Regexp }
+
+func compileURLPattern(glob string) (urlPattern, error) {
+	parts := strings.Split(glob, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	re, err := regexp.Compile("^" + strings.Join(parts, ".*") + "$")
+	if err != nil {
+		return urlPattern{}, err
+	}
+	return urlPattern{re: re}, nil
+}
+
+func (p urlPattern) match(url string) bool { return p.re.MatchString(url) }
+
+type responseWatch struct {
+	patterns []urlPattern
+	fn       func(NetworkResponse)
+}
+
+func (w *responseWatch) wants(url string) bool {
+	for _, p := range w.patterns {
+		if p.match(url) {
+			return true
+		}
+	}
+	return false
+}
+
+// responseWatchers fans one page-level response subscription out to every
+// registered watch. Watches outlive pages: open re-attaches them to each new
+// page.
+type responseWatchers struct {
+	mu      sync.Mutex
+	watches []*responseWatch
+	// stop detaches from the current page; nil while detached.
+	stop func()
+}
+
+func (n *responseWatchers) add(w *responseWatch) func() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.watches = append(n.watches, w)
+	return func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		for i, o := range n.watches {
+			if o == w {
+				n.watches = append(n.watches[:i], n.watches[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (n *responseWatchers) active() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.watches) > 0
+}
+
+func (n *responseWatchers) attached() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.stop != nil
+}
+
+// attach subscribes to p's responses, dropping any previous page.
+func (n *responseWatchers) attach(p Page) error {
+	n.detach()
+	stop, err := p.OnResponse(n.dispatch)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.stop = stop
+	n.mu.Unlock()
+	return nil
+}
+
+func (n *responseWatchers) detach() {
+	n.mu.Lock()
+	stop := n.stop
+	n.stop = nil
+	n.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+func (n *responseWatchers) dispatch(r NetworkResponse) {
+	n.mu.Lock()
+	var fns []func(NetworkResponse)
+	for _, w := range n.watches {
+		if w.wants(r.URL) {
+			fns = append(fns, w.fn)
+		}
+	}
+	n.mu.Unlock()
+	for _, fn := range fns {
+		fn(r)
+	}
+}
+
+// watchResponses calls fn for every XHR/fetch response whose URL matches one
+// of patterns, on the current page and any page opened later. Watches added
+// before Open see the responses of the initial load.
+func (e *Engine) watchResponses(patterns []string, fn func(NetworkResponse)) (func(), error) {
+	w := &responseWatch{fn: fn}
+	for _, glob := range patterns {
+		p, err := compileURLPattern(glob)
+		if err != nil {
+			return nil, err
+		}
+		w.patterns = append(w.patterns, p)
+	}
+	stop := e.net.add(w)
+	if e.page != nil && !e.net.attached() {
+		if err := e.net.attach(e.page); err != nil {
+			stop()
+			return nil, err
+		}
+	}
+	return stop, nil
+}
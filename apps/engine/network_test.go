@@ -0,0 +1,130 @@
+package engine_test
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+type responseLog struct {
+	mu   sync.Mutex
+	urls []string
+}
+
+func (l *responseLog) add(r engine.NetworkResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.urls = append(l.urls, r.URL)
+}
+
+func (l *responseLog) got() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.urls...)
+}
+
+func TestWatchResponsesBeforeOpenSeesMatchingResponses(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	drv := engine.NewDriver(engine.NewWithBrowser(engine.DefaultConfig(), fb))
+	ctx := context.Background()
+
+	var log responseLog
+	stop, err := drv.WatchResponses(ctx, []string{"*/api?SERVER_GET_*", "https://cdn.test/*.json"}, log.add)
+	if err != nil {
+		t.Fatalf("WatchResponses returned error: %v", err)
+	}
+	if err := drv.Open(ctx, "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if got := fb.Opened(); !reflect.DeepEqual(got, []string{"https://example.test/app"}) {
+		t.Fatalf("unexpected navigations: %v", got)
+	}
+
+	fb.RespondJSON("https://example.test/api?SERVER_GET_ENCOUNTERS", []byte(`{}`))
+	fb.RespondJSON("https://example.test/api?SERVER_VOTE", []byte(`{}`))
+	fb.RespondJSON("https://cdn.test/a/b.json", []byte(`{}`))
+	stop()
+	fb.RespondJSON("https://example.test/api?SERVER_GET_CHAT", []byte(`{}`))
+
+	want := []string{"https://example.test/api?SERVER_GET_ENCOUNTERS", "https://cdn.test/a/b.json"}
+	if got := log.got(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("delivered %v, want %v", got, want)
+	}
+}
+
+func TestWatchResponsesAfterOpen(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	drv, _ := newTestDriver(t, fb)
+
+	var log responseLog
+	if _, err := drv.WatchResponses(context.Background(), []string{"*/deck"}, log.add); err != nil {
+		t.Fatalf("WatchResponses returned error: %v", err)
+	}
+	fb.RespondJSON("https://example.test/deck", []byte(`{}`))
+	if got := log.got(); len(got) != 1 {
+		t.Fatalf("expected one response, got %v", got)
+	}
+}
+
+func TestNetworkResponseDecodeJSON(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		Name string `json:"name"`
+	}
+	if err := (engine.NetworkResponse{Body: []byte(`{"name":"Maya"}`)}).DecodeJSON(&v); err != nil || v.Name != "Maya" {
+		t.Fatalf("DecodeJSON = %+v, %v", v, err)
+	}
+}
+
+func TestReplayDriverDeliversRecordedResponses(t *testing.T) {
+	t.Parallel()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	patterns := []string{"*/deck"}
+	ctx := context.Background()
+
+	fb := fakebrowser.New()
+	fb.Show("#ready")
+	drv, _ := newTestDriver(t, fb)
+	if err := drv.StartTrace(tracePath); err != nil {
+		t.Fatalf("StartTrace returned error: %v", err)
+	}
+	if _, err := drv.WatchResponses(ctx, patterns, func(engine.NetworkResponse) {}); err != nil {
+		t.Fatalf("WatchResponses returned error: %v", err)
+	}
+	fb.RespondJSON("https://example.test/deck", []byte(`{"n":1}`))
+	if err := drv.WaitAnyVisible(ctx, []string{"#ready"}); err != nil {
+		t.Fatalf("WaitAnyVisible returned error: %v", err)
+	}
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	rp, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	var bodies []string
+	if _, err := rp.WatchResponses(ctx, patterns, func(r engine.NetworkResponse) { bodies = append(bodies, string(r.Body)) }); err != nil {
+		t.Fatalf("WatchResponses returned error: %v", err)
+	}
+	if len(bodies) != 0 {
+		t.Fatalf("response delivered before the call it preceded: %v", bodies)
+	}
+	if err := rp.WaitAnyVisible(ctx, []string{"#ready"}); err != nil {
+		t.Fatalf("WaitAnyVisible returned error: %v", err)
+	}
+	if !reflect.DeepEqual(bodies, []string{`{"n":1}`}) {
+		t.Fatalf("replayed bodies %v", bodies)
+	}
+}
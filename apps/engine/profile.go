@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/launcher/flags"
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a named browser setup: how a launched browser starts and which
+// device every page emulates, so screenshots have the same layout on any
+// machine. Profiles are kept in a YAML file keyed by name (see LoadProfile).
+type Profile struct {
+	Name string `yaml:"-"`
+
+	// Launch settings; a browser attached through ControlURL can't take them.
+	UserDataDir string `yaml:"user_data_dir"`
+	Proxy       string `yaml:"proxy"`        // host:port or scheme://host:port
+	ProxyBypass string `yaml:"proxy_bypass"` // e.g. "localhost;*.internal"
+
+	Device Device `yaml:",inline"`
+}
+
+// Device is the emulation applied to every page before it loads. Zero
+// fields keep the browser's own value.
+type Device struct {
+	Width       int     `yaml:"width"` // viewport in CSS pixels; with Height
+	Height      int     `yaml:"height"`
+	ScaleFactor float64 `yaml:"scale_factor"` // device pixels per CSS pixel
+	Mobile      bool    `yaml:"mobile"`       // meta viewport, overlay scrollbars
+	Touch       bool    `yaml:"touch"`
+	UserAgent   string  `yaml:"user_agent"`
+	Locale      string  `yaml:"locale"`   // BCP 47, e.g. "en-GB"; also Accept-Language
+	Timezone    string  `yaml:"timezone"` // IANA, e.g. "Europe/London"
+	// Geolocation, when set, is granted to every origin and pinned here.
+	Geolocation *Geolocation `yaml:"geolocation"`
+}
+
+type Geolocation struct {
+	Latitude  float64 `yaml:"latitude"`
+	Longitude float64 `yaml:"longitude"`
+	Accuracy  float64 `yaml:"accuracy"` // metres
+}
+
+func (d Device) empty() bool { return d == Device{} }
+
+func (p Profile) launches() bool { return p.UserDataDir != "" || p.Proxy != "" || p.ProxyBypass != "" }
+
+// LoadProfile reads the profile called name from the YAML file at path.
+func LoadProfile(path, name string) (Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	defer f.Close()
+	var all map[string]Profile
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&all); err != nil {
+		return Profile{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	p, ok := all[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%s: no profile %q (have %s)", path, name, strings.Join(slices.Sorted(maps.Keys(all)), ", "))
+	}
+	p.Name = name
+	if err := p.validate(); err != nil {
+		return Profile{}, fmt.Errorf("%s: profile %s: %w", path, name, err)
+	}
+	return p, nil
+}
+
+func (p Profile) validate() error {
+	d := p.Device
+	switch {
+	case d.Width < 0 || d.Height < 0 || (d.Width == 0) != (d.Height == 0):
+		return errors.New("width and height must be set together")
+	case d.ScaleFactor < 0:
+		return errors.New("scale_factor must not be negative")
+	case (d.Mobile || d.ScaleFactor > 0) && d.Width == 0:
+		return errors.New("mobile and scale_factor need width and height")
+	}
+	if g := d.Geolocation; g != nil && (g.Latitude < -90 || g.Latitude > 90 || g.Longitude < -180 || g.Longitude > 180 || g.Accuracy < 0) {
+		return fmt.Errorf("geolocation %v,%v out of range", g.Latitude, g.Longitude)
+	}
+	return nil
+}
+
+// launcher returns the launcher for a browser started with p.
+func (p Profile) launcher(headless bool) *launcher.Launcher {
+	l := launcher.New().Headless(headless)
+	if p.UserDataDir != "" {
+		l = l.UserDataDir(p.UserDataDir)
+	}
+	if p.Proxy != "" {
+		l = l.Proxy(p.Proxy)
+	}
+	if p.ProxyBypass != "" {
+		l = l.Set(flags.Flag("proxy-bypass-list"), p.ProxyBypass)
+	}
+	if p.Device.Locale != "" {
+		l = l.Set(flags.Flag("lang"), p.Device.Locale)
+	}
+	if d := p.Device; d.Width > 0 {
+		l = l.Set(flags.Flag("window-size"), fmt.Sprintf("%d,%d", d.Width, d.Height))
+	}
+	return l
+}
+
+// acceptLanguage turns "en-GB" into "en-GB,en;q=0.9".
+func acceptLanguage(locale string) string {
+	base, _, found := strings.Cut(locale, "-")
+	if !found {
+		return locale
+	}
+	return locale + "," + base + ";q=0.9"
+}
@@ -0,0 +1,105 @@
+package engine_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+const testProfiles = `
+desktop:
+  width: 1280
+  height: 800
+pixel7:
+  width: 412
+  height: 915
+  scale_factor: 2.625
+  mobile: true
+  touch: true
+  user_agent: "Mozilla/5.0 (Linux; Android 14; Pixel 7)"
+  locale: en-GB
+  timezone: Europe/London
+  geolocation: {latitude: 51.5072, longitude: -0.1276, accuracy: 50}
+  proxy: "socks5://127.0.0.1:1080"
+broken:
+  width: 412
+`
+
+func writeProfiles(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write profiles: %v", err)
+	}
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	t.Parallel()
+
+	path := writeProfiles(t, testProfiles)
+	p, err := engine.LoadProfile(path, "pixel7")
+	if err != nil {
+		t.Fatalf("LoadProfile returned error: %v", err)
+	}
+	want := engine.Profile{
+		Name:  "pixel7",
+		Proxy: "socks5://127.0.0.1:1080",
+		Device: engine.Device{
+			Width: 412, Height: 915, ScaleFactor: 2.625, Mobile: true, Touch: true,
+			UserAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 7)",
+			Locale:    "en-GB", Timezone: "Europe/London",
+			Geolocation: &engine.Geolocation{Latitude: 51.5072, Longitude: -0.1276, Accuracy: 50},
+		},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Fatalf("LoadProfile = %+v, want %+v", p, want)
+	}
+
+	if _, err := engine.LoadProfile(path, "tablet"); err == nil || !strings.Contains(err.Error(), "broken, desktop, pixel7") {
+		t.Fatalf("unknown profile error = %v, want the names on offer", err)
+	}
+	if _, err := engine.LoadProfile(path, "broken"); err == nil || !strings.Contains(err.Error(), "width and height") {
+		t.Fatalf("width-only profile error = %v", err)
+	}
+	if _, err := engine.LoadProfile(writeProfiles(t, "x:\n  viewport: 3\n"), "x"); err == nil {
+		t.Fatal("unknown field accepted")
+	}
+}
+
+func TestOpenEmulatesDeviceBeforeLoading(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	cfg := engine.DefaultConfig()
+	cfg.Profile = engine.Profile{Name: "phone", Device: engine.Device{Width: 412, Height: 915, Mobile: true, ScaleFactor: 2}}
+	drv := engine.NewDriver(engine.NewWithBrowser(cfg, fb))
+	if err := drv.Open(context.Background(), "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	got := fb.Emulated()
+	if len(got) != 1 || got[0].Device != cfg.Profile.Device {
+		t.Fatalf("Emulated = %+v, want the profile's device once", got)
+	}
+	if got[0].Opened != 0 || !reflect.DeepEqual(fb.Opened(), []string{"https://example.test/app"}) {
+		t.Fatalf("emulated after %d loads of %v, want before the first", got[0].Opened, fb.Opened())
+	}
+}
+
+func TestNewRejectsLaunchProfileForRunningBrowser(t *testing.T) {
+	t.Parallel()
+
+	cfg := engine.DefaultConfig()
+	cfg.ControlURL = "127.0.0.1:9222"
+	cfg.Profile = engine.Profile{Name: "persistent", UserDataDir: t.TempDir()}
+	if _, err := engine.New(cfg); err == nil || !strings.Contains(err.Error(), "launched browser") {
+		t.Fatalf("New error = %v, want launch-only settings rejected", err)
+	}
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// aliveTimeout bounds the probe of Alive; a healthy tab answers at once.
+const aliveTimeout = 5 * time.Second
+
+func (d *Driver) Alive(ctx context.Context) error {
+	start := time.Now()
+	err := ctx.Err()
+	if err == nil {
+		err = d.e.alive()
+	}
+	d.tracer.Record(TraceEntry{Op: TraceOpAlive}, start, err)
+	return err
+}
+
+func (d *Driver) Reconnect(ctx context.Context) error {
+	start := time.Now()
+	err := ctx.Err()
+	if err == nil {
+		err = d.e.reconnect()
+	}
+	d.tracer.Record(TraceEntry{Op: TraceOpReconnect}, start, err)
+	return err
+}
+
+func (e *Engine) alive() error {
+	if err := e.RequirePage(); err != nil {
+		return err
+	}
+	if err := e.page.Timeout(aliveTimeout).Alive(); err != nil {
+		return fmt.Errorf("%w: %w", ErrConnectionLost, err)
+	}
+	return nil
+}
+
+// reconnect drops the page and the browser connection and dials a new one.
+// Response watches stay registered and attach to the next page opened. A
+// relaunched browser starts from a fresh profile, so Config.SessionFile is
+// restored again on the next open.
+func (e *Engine) reconnect() error {
+	if e.dial == nil {
+		return fmt.Errorf("%w: browser was handed in, cannot reconnect", ErrConnectionFailed)
+	}
+	e.net.detach()
+	if e.page != nil {
+		_ = e.page.Close()
+		e.page = nil
+	}
+	if e.ownsBrowser {
+		_ = e.browser.Close()
+	}
+	b, owns, err := e.dial()
+	if err != nil {
+		return err
+	}
+	e.browser, e.ownsBrowser = b, owns
+	if owns {
+		e.sessionLoaded = false
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func TestDriverReconnectsAfterLostTab(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	dials := 0
+	eng, err := engine.NewWithDialer(engine.DefaultConfig(), func() (engine.Browser, error) {
+		dials++
+		return fb, nil
+	})
+	if err != nil {
+		t.Fatalf("NewWithDialer returned error: %v", err)
+	}
+	drv := engine.NewDriver(eng)
+	ctx := context.Background()
+	if err := drv.Open(ctx, "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if err := drv.Alive(ctx); err != nil {
+		t.Fatalf("Alive returned error on a live tab: %v", err)
+	}
+
+	fb.Disconnect()
+	if err := drv.Alive(ctx); !errors.Is(err, engine.ErrConnectionLost) {
+		t.Fatalf("expected ErrConnectionLost, got %v", err)
+	}
+	if err := drv.Reconnect(ctx); err != nil {
+		t.Fatalf("Reconnect returned error: %v", err)
+	}
+	if err := drv.Alive(ctx); !errors.Is(err, engine.ErrPageNotOpen) {
+		t.Fatalf("expected ErrPageNotOpen before reopening, got %v", err)
+	}
+	if err := drv.Open(ctx, "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if err := drv.Alive(ctx); err != nil {
+		t.Fatalf("Alive returned error after reconnect: %v", err)
+	}
+
+	if dials != 2 {
+		t.Fatalf("dialed %d times, want 2", dials)
+	}
+	if got, want := fb.Opened(), []string{"https://example.test/app", "https://example.test/app"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("opened = %v, want %v", got, want)
+	}
+}
+
+func TestReconnectNeedsDialer(t *testing.T) {
+	t.Parallel()
+
+	drv, _ := newTestDriver(t, fakebrowser.New())
+	if err := drv.Reconnect(context.Background()); !errors.Is(err, engine.ErrConnectionFailed) {
+		t.Fatalf("expected ErrConnectionFailed, got %v", err)
+	}
+}
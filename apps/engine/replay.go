@@ -0,0 +1,309 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// ErrTraceMismatch means the caller diverged from the recorded session (a
+// different call, or the same call with different selectors).
+var ErrTraceMismatch = errors.New("engine: replay diverged from trace")
+
+// ReplayDriver implements IDriver by serving recorded results from a trace,
+// in order, so an adapter session can be reproduced offline.
+type ReplayDriver struct {
+	mu      sync.Mutex
+	entries []TraceEntry
+	next    int
+	dir     string
+	// watches[i] receives the recorded responses of the (i+1)th
+	// WatchResponses call; nil once stopped.
+	watches []func(NetworkResponse)
+}
+
+func NewReplayDriver(tracePath string) (*ReplayDriver, error) {
+	entries, err := LoadTrace(tracePath)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplayDriver{entries: entries, dir: filepath.Dir(tracePath)}, nil
+}
+
+var _ IDriver = (*ReplayDriver)(nil)
+
+// Remaining is the number of recorded calls not yet replayed.
+func (r *ReplayDriver) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries) - r.next
+}
+
+// Close delivers any responses recorded after the last call.
+func (r *ReplayDriver) Close() error {
+	r.deliverResponses()
+	return nil
+}
+
+// MarkLoggedIn is a no-op: a replay saves no session.
+func (r *ReplayDriver) MarkLoggedIn(bool) {}
+
+func (r *ReplayDriver) Open(ctx context.Context, url string) error {
+	en, err := r.take(ctx, TraceOpOpen, nil)
+	if err != nil {
+		return err
+	}
+	return errorFromEntry(en)
+}
+
+func (r *ReplayDriver) Screenshot(ctx context.Context, filePath string) error {
+	en, err := r.take(ctx, TraceOpScreenshot, nil)
+	if err != nil {
+		return err
+	}
+	return r.restoreShot(en, filePath)
+}
+
+func (r *ReplayDriver) ScreenshotElement(ctx context.Context, selector string, filePath string) error {
+	en, err := r.take(ctx, TraceOpScreenshotElement, []string{selector})
+	if err != nil {
+		return err
+	}
+	return r.restoreShot(en, filePath)
+}
+
+func (r *ReplayDriver) WaitAnyVisible(ctx context.Context, selectors []string) error {
+	en, err := r.take(ctx, TraceOpWaitAnyVisible, selectors)
+	if err != nil {
+		return err
+	}
+	return errorFromEntry(en)
+}
+
+func (r *ReplayDriver) IsVisible(ctx context.Context, selectors []string) (bool, error) {
+	en, err := r.take(ctx, TraceOpIsVisible, selectors)
+	if err != nil {
+		return false, err
+	}
+	return en.Visible != nil && *en.Visible, errorFromEntry(en)
+}
+
+func (r *ReplayDriver) ClickBySelectors(ctx context.Context, selectors []string) error {
+	en, err := r.take(ctx, TraceOpClick, selectors)
+	if err != nil {
+		return err
+	}
+	return errorFromEntry(en)
+}
+
+func (r *ReplayDriver) TypeText(ctx context.Context, selectors []string, text string) error {
+	en, err := r.take(ctx, TraceOpTypeText, selectors)
+	if err != nil {
+		return err
+	}
+	if en.Input != text {
+		return fmt.Errorf("%w: call %d typed %q, got %q", ErrTraceMismatch, en.Seq, en.Input, text)
+	}
+	return errorFromEntry(en)
+}
+
+// TypeSecret can't check the text, which the trace doesn't have (and a
+// login code differs every time anyway).
+func (r *ReplayDriver) TypeSecret(ctx context.Context, selectors []string, _ string) error {
+	en, err := r.take(ctx, TraceOpTypeSecret, selectors)
+	if err != nil {
+		return err
+	}
+	return errorFromEntry(en)
+}
+
+func (r *ReplayDriver) Texts(ctx context.Context, selector string) ([]string, error) {
+	en, err := r.take(ctx, TraceOpTexts, []string{selector})
+	if err != nil {
+		return nil, err
+	}
+	return en.Texts, errorFromEntry(en)
+}
+
+func (r *ReplayDriver) Attribute(ctx context.Context, selector, name string) (string, bool, error) {
+	en, err := r.take(ctx, TraceOpAttribute, []string{selector})
+	if err != nil {
+		return "", false, err
+	}
+	if en.Attr != name {
+		return "", false, fmt.Errorf("%w: call %d attribute %q, got %q", ErrTraceMismatch, en.Seq, en.Attr, name)
+	}
+	if en.Value == nil {
+		return "", false, errorFromEntry(en)
+	}
+	return *en.Value, true, errorFromEntry(en)
+}
+
+func (r *ReplayDriver) Records(ctx context.Context, selector string, fields map[string]string) ([]map[string]string, error) {
+	en, err := r.take(ctx, TraceOpRecords, []string{selector})
+	if err != nil {
+		return nil, err
+	}
+	if !maps.Equal(en.Fields, fields) {
+		return nil, fmt.Errorf("%w: call %d fields %v, got %v", ErrTraceMismatch, en.Seq, en.Fields, fields)
+	}
+	return en.Records, errorFromEntry(en)
+}
+
+func (r *ReplayDriver) ScreenshotSections(ctx context.Context, selector string, pathFor func(i int) string) ([]string, error) {
+	en, err := r.take(ctx, TraceOpScreenshotSections, []string{selector})
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(en.Screenshots))
+	for i, ref := range en.Screenshots {
+		path := pathFor(i + 1)
+		if err := r.copyOut(ref, path); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, errorFromEntry(en)
+}
+
+// Cookies returns the recorded cookies; traces don't keep their values.
+func (r *ReplayDriver) Cookies(ctx context.Context) ([]Cookie, error) {
+	en, err := r.take(ctx, TraceOpCookies, nil)
+	if err != nil {
+		return nil, err
+	}
+	return en.Cookies, errorFromEntry(en)
+}
+
+func (r *ReplayDriver) SetCookies(ctx context.Context, cookies []Cookie) error {
+	en, err := r.take(ctx, TraceOpSetCookies, nil)
+	if err != nil {
+		return err
+	}
+	return errorFromEntry(en)
+}
+
+func (r *ReplayDriver) WaitFor(ctx context.Context, conds ...Wait) error {
+	en, err := r.take(ctx, TraceOpWaitFor, waitSelectors(conds))
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(en.Waits, conds) {
+		return fmt.Errorf("%w: call %d waits for %v, got %v", ErrTraceMismatch, en.Seq, en.Waits, conds)
+	}
+	return errorFromEntry(en)
+}
+
+func (r *ReplayDriver) Alive(ctx context.Context) error {
+	en, err := r.take(ctx, TraceOpAlive, nil)
+	if err != nil {
+		return err
+	}
+	return errorFromEntry(en)
+}
+
+func (r *ReplayDriver) Reconnect(ctx context.Context) error {
+	en, err := r.take(ctx, TraceOpReconnect, nil)
+	if err != nil {
+		return err
+	}
+	return errorFromEntry(en)
+}
+
+// WatchResponses replays the responses recorded for this watch, each one
+// delivered just before the call that followed it in the recording.
+func (r *ReplayDriver) WatchResponses(ctx context.Context, patterns []string, fn func(NetworkResponse)) (func(), error) {
+	en, err := r.take(ctx, TraceOpWatchResponses, patterns)
+	if err != nil {
+		return nil, err
+	}
+	if err := errorFromEntry(en); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.watches = append(r.watches, fn)
+	idx := len(r.watches) - 1
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.watches[idx] = nil
+	}, nil
+}
+
+// deliverResponses pops the response entries at the head of the trace and
+// hands them to their watches, outside the lock.
+func (r *ReplayDriver) deliverResponses() {
+	type delivery struct {
+		fn  func(NetworkResponse)
+		res NetworkResponse
+	}
+	var out []delivery
+	r.mu.Lock()
+	for r.next < len(r.entries) && r.entries[r.next].Op == TraceOpResponse {
+		en := r.entries[r.next]
+		r.next++
+		if en.Watch < 1 || en.Watch > len(r.watches) || r.watches[en.Watch-1] == nil {
+			continue
+		}
+		out = append(out, delivery{
+			fn:  r.watches[en.Watch-1],
+			res: NetworkResponse{URL: en.URL, Status: en.Status, MIMEType: en.MIMEType, Body: []byte(en.Body)},
+		})
+	}
+	r.mu.Unlock()
+	for _, d := range out {
+		d.fn(d.res)
+	}
+}
+
+// take pops the next entry and checks it matches the call being made.
+// Selectors are only compared when the call passes them.
+func (r *ReplayDriver) take(ctx context.Context, op TraceOp, selectors []string) (TraceEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return TraceEntry{}, err
+	}
+	r.deliverResponses()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.next >= len(r.entries) {
+		return TraceEntry{}, fmt.Errorf("%w: trace exhausted at %s", ErrTraceMismatch, op)
+	}
+	en := r.entries[r.next]
+	if en.Op != op {
+		return TraceEntry{}, fmt.Errorf("%w: call %d is %s, got %s", ErrTraceMismatch, en.Seq, en.Op, op)
+	}
+	if selectors != nil && !slices.Equal(en.Selectors, selectors) {
+		return TraceEntry{}, fmt.Errorf("%w: call %d %s selectors %v, got %v", ErrTraceMismatch, en.Seq, op, en.Selectors, selectors)
+	}
+	r.next++
+	return en, nil
+}
+
+func (r *ReplayDriver) restoreShot(en TraceEntry, filePath string) error {
+	if err := errorFromEntry(en); err != nil {
+		return err
+	}
+	if en.Screenshot == "" {
+		return fmt.Errorf("%w: call %d has no screenshot", ErrTraceMismatch, en.Seq)
+	}
+	return r.copyOut(en.Screenshot, filePath)
+}
+
+// copyOut writes the trace screenshot ref (relative to the trace) to filePath.
+func (r *ReplayDriver) copyOut(ref, filePath string) error {
+	buf, err := os.ReadFile(filepath.Join(r.dir, ref))
+	if err != nil {
+		return err
+	}
+	if err := ensureDir(filePath); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, buf, 0o644)
+}
@@ -4,25 +4,35 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/vd09-projects/swipeassist/internal/retry"
 )
 
-func (e *Engine) retry(ctx context.Context, fn func() error) error {
-	var last error
-	for i := 0; i < e.cfg.RetryAttempts; i++ {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
-		err := fn()
-		if err == nil {
-			return nil
-		}
-		last = err
-		_ = sleepCtx(ctx, e.cfg.RetryDelay)
+// retry runs fn under the Config's retry policy; op names it for
+// Config.RetryObserver.
+func (e *Engine) retry(ctx context.Context, op TraceOp, fn func() error) error {
+	return e.retryPolicy().Do(ctx, string(op), fn)
+}
+
+func (e *Engine) retryPolicy() retry.Policy {
+	return retry.Policy{
+		Attempts:  e.cfg.RetryAttempts,
+		BaseDelay: e.cfg.RetryDelay,
+		MaxDelay:  e.cfg.RetryMaxDelay,
+		Jitter:    e.cfg.RetryJitter,
+		Budget:    e.cfg.RetryBudget,
+		Classify:  classify,
+		Observe:   e.cfg.RetryObserver,
 	}
-	if last == nil {
-		last = errors.New("retry failed")
+}
+
+// classify makes a missing element, page or browser fatal: the lookup
+// already waited StepTimeout, and another try would fail the same way.
+func classify(err error) retry.Class {
+	if errors.Is(err, ErrElementNotFound) || errors.Is(err, ErrPageNotOpen) || errors.Is(err, ErrConnectionLost) {
+		return retry.Fatal
 	}
-	return last
+	return retry.DefaultClassify(err)
 }
 
 func sleepCtx(ctx context.Context, d time.Duration) error {
@@ -34,4 +44,4 @@ func sleepCtx(ctx context.Context, d time.Duration) error {
 	case <-t.C:
 		return nil
 	}
-}
\ No newline at end of file
+}
@@ -1,6 +1,10 @@
 package engine
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -8,35 +12,96 @@ import (
 )
 
 type Browser interface {
-	MustPage(url string) Page
-	MustClose()
+	Page(url string) (Page, error)
+	Close() error
 }
 
 type Page interface {
-	MustWaitLoad() Page
+	WaitLoad() error
+	Navigate(url string) error
 	Timeout(d time.Duration) Page
 	Element(selector string) (Element, error)
+	// Elements returns every current match without waiting.
+	Elements(selector string) ([]Element, error)
 	Screenshot(fullPage bool, opt *proto.PageCaptureScreenshot) ([]byte, error)
-	MustClose()
+	Close() error
+	// Alive fails once the tab is closed or the browser connection dropped.
+	Alive() error
+	// TrackRequests starts counting the page's in-flight requests. Only
+	// requests sent after it are seen, so call it before navigating.
+	TrackRequests() error
+	// WaitRequestIdle blocks until no tracked request has been in flight for
+	// quiet; bound it with Timeout.
+	WaitRequestIdle(quiet time.Duration) error
+	// Emulate applies d to the page; it holds across navigations.
+	Emulate(d Device) error
+
+	// OnResponse calls fn with every XHR/fetch response the page finishes
+	// loading until stop is called. fn runs on the event goroutine.
+	OnResponse(fn func(NetworkResponse)) (stop func(), err error)
+
+	// Pointer input in viewport CSS pixels.
+	MouseMove(to proto.Point) error
+	MouseClick() error
+	MouseWheel(dx, dy float64) error
+
+	// InsertText types text into the focused element.
+	InsertText(text string) error
+
+	// Cookies returns the cookies sent to the page's current URL.
+	Cookies() ([]Cookie, error)
+	SetCookies(cookies []Cookie) error
+	// Storage reads localStorage and IndexedDB of the page's origin.
+	Storage() (OriginStorage, error)
+	SetStorage(s OriginStorage) error
 }
 
 type Element interface {
-	MustClick()
+	Click() error
 	ScrollIntoView() error
 	EvalBool(js string) (bool, error)
 	Screenshot(format proto.PageCaptureScreenshotFormat, quality int) ([]byte, error)
+	// Box is the element's bounding rectangle in viewport CSS pixels.
+	Box() (*proto.DOMRect, error)
+	// HitAt reports whether a click at pt (viewport CSS pixels) would land
+	// on the element or one of its descendants rather than on something
+	// covering it.
+	HitAt(pt proto.Point) (bool, error)
+
+	Text() (string, error)
+	// Attribute returns nil when the attribute is absent.
+	Attribute(name string) (*string, error)
+	// Elements returns every current descendant match without waiting.
+	Elements(selector string) ([]Element, error)
+
+	// QuietFor is the time since the last DOM mutation in the element's
+	// subtree; the first call starts watching and returns 0.
+	QuietFor() (time.Duration, error)
+	// ImagesDecoded reports whether every <img> in the element (or the
+	// element itself) has loaded and decoded.
+	ImagesDecoded() (bool, error)
 }
 
 type RodBrowser struct{ Inner *rod.Browser }
 
-func (b RodBrowser) MustPage(url string) Page { return RodPage{Inner: b.Inner.MustPage(url)} }
-func (b RodBrowser) MustClose()               { b.Inner.MustClose() }
+func (b RodBrowser) Page(url string) (Page, error) {
+	p, err := b.Inner.Page(proto.TargetCreateTarget{URL: url})
+	if err != nil {
+		return nil, err
+	}
+	return RodPage{Inner: p, reqs: &inflight{ids: map[proto.NetworkRequestID]bool{}}}, nil
+}
+func (b RodBrowser) Close() error { return b.Inner.Close() }
 
-type RodPage struct{ Inner *rod.Page }
+type RodPage struct {
+	Inner *rod.Page
+	reqs  *inflight // shared with Timeout copies
+}
 
-func (p RodPage) MustWaitLoad() Page { p.Inner.MustWaitLoad(); return p }
+func (p RodPage) WaitLoad() error           { return p.Inner.WaitLoad() }
+func (p RodPage) Navigate(url string) error { return p.Inner.Navigate(url) }
 func (p RodPage) Timeout(d time.Duration) Page {
-	return RodPage{Inner: p.Inner.Timeout(d)}
+	return RodPage{Inner: p.Inner.Timeout(d), reqs: p.reqs}
 }
 func (p RodPage) Element(selector string) (Element, error) {
 	el, err := p.Inner.Element(selector)
@@ -45,24 +110,314 @@ func (p RodPage) Element(selector string) (Element, error) {
 	}
 	return RodElement{Inner: el}, nil
 }
+func (p RodPage) Elements(selector string) ([]Element, error) {
+	els, err := p.Inner.Elements(selector)
+	if err != nil {
+		return nil, err
+	}
+	return wrapElements(els), nil
+}
 func (p RodPage) Screenshot(fullPage bool, opt *proto.PageCaptureScreenshot) ([]byte, error) {
 	return p.Inner.Screenshot(fullPage, opt)
 }
-func (p RodPage) MustClose() { _ = p.Inner.Close() }
+func (p RodPage) Close() error {
+	if p.reqs != nil {
+		p.reqs.stopTracking()
+	}
+	return p.Inner.Close()
+}
+func (p RodPage) Alive() error {
+	_, err := p.Inner.Eval(`() => true`)
+	return err
+}
+
+// idleIgnoredTypes never count as in flight: sockets stay open, and images,
+// media and fonts are covered by ImagesLoaded.
+var idleIgnoredTypes = map[proto.NetworkResourceType]bool{
+	proto.NetworkResourceTypeWebSocket:   true,
+	proto.NetworkResourceTypeEventSource: true,
+	proto.NetworkResourceTypeMedia:       true,
+	proto.NetworkResourceTypeImage:       true,
+	proto.NetworkResourceTypeFont:        true,
+}
+
+// inflight is the set of a page's requests that have been sent and have not
+// finished or failed, and when it last changed.
+type inflight struct {
+	mu      sync.Mutex
+	ids     map[proto.NetworkRequestID]bool
+	changed time.Time
+	stop    func() // nil until TrackRequests
+}
+
+func (f *inflight) set(id proto.NetworkRequestID, busy bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if busy == f.ids[id] {
+		return
+	}
+	if busy {
+		f.ids[id] = true
+	} else {
+		delete(f.ids, id)
+	}
+	f.changed = time.Now()
+}
+
+// idleFor is how long no request has been in flight; zero while one is.
+func (f *inflight) idleFor() time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.ids) > 0 {
+		return 0
+	}
+	return time.Since(f.changed)
+}
+
+func (f *inflight) stopTracking() {
+	f.mu.Lock()
+	stop := f.stop
+	f.stop = nil
+	f.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+func (p RodPage) TrackRequests() error {
+	if err := (proto.NetworkEnable{}).Call(p.Inner); err != nil {
+		return err
+	}
+	page, cancel := p.Inner.WithCancel()
+	p.reqs.mu.Lock()
+	p.reqs.stop, p.reqs.changed = cancel, time.Now()
+	p.reqs.mu.Unlock()
+	wait := page.EachEvent(func(ev *proto.NetworkRequestWillBeSent) {
+		if !idleIgnoredTypes[ev.Type] {
+			p.reqs.set(ev.RequestID, true)
+		}
+	}, func(ev *proto.NetworkLoadingFinished) {
+		p.reqs.set(ev.RequestID, false)
+	}, func(ev *proto.NetworkLoadingFailed) {
+		p.reqs.set(ev.RequestID, false)
+	})
+	go wait()
+	return nil
+}
+
+func (p RodPage) WaitRequestIdle(quiet time.Duration) error {
+	ctx := p.Inner.GetContext()
+	for {
+		idle := p.reqs.idleFor()
+		if idle >= quiet {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(min(quiet-idle, settlePoll)):
+		}
+	}
+}
+
+func (p RodPage) Emulate(d Device) error {
+	if d.Width > 0 {
+		metrics := proto.EmulationSetDeviceMetricsOverride{
+			Width:             d.Width,
+			Height:            d.Height,
+			DeviceScaleFactor: d.ScaleFactor,
+			Mobile:            d.Mobile,
+		}
+		if err := metrics.Call(p.Inner); err != nil {
+			return fmt.Errorf("viewport: %w", err)
+		}
+	}
+	if d.Touch {
+		points := 5
+		if err := (proto.EmulationSetTouchEmulationEnabled{Enabled: true, MaxTouchPoints: &points}).Call(p.Inner); err != nil {
+			return fmt.Errorf("touch: %w", err)
+		}
+	}
+	if d.UserAgent != "" || d.Locale != "" {
+		// The override needs a user agent even when only the language changes.
+		ua := d.UserAgent
+		if ua == "" {
+			v, err := proto.BrowserGetVersion{}.Call(p.Inner)
+			if err != nil {
+				return fmt.Errorf("user agent: %w", err)
+			}
+			ua = v.UserAgent
+		}
+		override := proto.NetworkSetUserAgentOverride{UserAgent: ua, AcceptLanguage: acceptLanguage(d.Locale)}
+		if err := override.Call(p.Inner); err != nil {
+			return fmt.Errorf("user agent: %w", err)
+		}
+	}
+	if d.Locale != "" {
+		if err := (proto.EmulationSetLocaleOverride{Locale: strings.ReplaceAll(d.Locale, "-", "_")}).Call(p.Inner); err != nil {
+			return fmt.Errorf("locale: %w", err)
+		}
+	}
+	if d.Timezone != "" {
+		if err := (proto.EmulationSetTimezoneOverride{TimezoneID: d.Timezone}).Call(p.Inner); err != nil {
+			return fmt.Errorf("timezone: %w", err)
+		}
+	}
+	if g := d.Geolocation; g != nil {
+		grant := proto.BrowserGrantPermissions{Permissions: []proto.BrowserPermissionType{proto.BrowserPermissionTypeGeolocation}}
+		if err := grant.Call(p.Inner.Browser()); err != nil {
+			return fmt.Errorf("geolocation permission: %w", err)
+		}
+		pin := proto.EmulationSetGeolocationOverride{Latitude: &g.Latitude, Longitude: &g.Longitude, Accuracy: &g.Accuracy}
+		if err := pin.Call(p.Inner); err != nil {
+			return fmt.Errorf("geolocation: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p RodPage) OnResponse(fn func(NetworkResponse)) (func(), error) {
+	if err := (proto.NetworkEnable{}).Call(p.Inner); err != nil {
+		return nil, err
+	}
+	page, cancel := p.Inner.WithCancel()
+
+	// The body is only available once loading finishes, so remember the
+	// response headers until then. Handlers run on one goroutine.
+	pending := map[proto.NetworkRequestID]*proto.NetworkResponse{}
+	wait := page.EachEvent(func(ev *proto.NetworkResponseReceived) {
+		if ev.Type == proto.NetworkResourceTypeXHR || ev.Type == proto.NetworkResourceTypeFetch {
+			pending[ev.RequestID] = ev.Response
+		}
+	}, func(ev *proto.NetworkLoadingFailed) {
+		delete(pending, ev.RequestID)
+	}, func(ev *proto.NetworkLoadingFinished) {
+		res, ok := pending[ev.RequestID]
+		if !ok {
+			return
+		}
+		delete(pending, ev.RequestID)
+		body, err := proto.NetworkGetResponseBody{RequestID: ev.RequestID}.Call(page)
+		if err != nil {
+			return
+		}
+		buf := []byte(body.Body)
+		if body.Base64Encoded {
+			if buf, err = base64.StdEncoding.DecodeString(body.Body); err != nil {
+				return
+			}
+		}
+		fn(NetworkResponse{URL: res.URL, Status: res.Status, MIMEType: res.MIMEType, Body: buf})
+	})
+	go wait()
+	return cancel, nil
+}
+
+func (p RodPage) MouseMove(to proto.Point) error { return p.Inner.Mouse.MoveTo(to) }
+func (p RodPage) MouseClick() error {
+	return wrapElement(p.Inner.Mouse.Click(proto.InputMouseButtonLeft, 1))
+}
+func (p RodPage) MouseWheel(dx, dy float64) error { return p.Inner.Mouse.Scroll(dx, dy, 1) }
+func (p RodPage) InsertText(text string) error    { return p.Inner.InsertText(text) }
+
+func (p RodPage) Cookies() ([]Cookie, error) {
+	cookies, err := p.Inner.Cookies(nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Cookie, 0, len(cookies))
+	for _, c := range cookies {
+		out = append(out, cookieFromProto(c))
+	}
+	return out, nil
+}
+
+func (p RodPage) SetCookies(cookies []Cookie) error {
+	params := make([]*proto.NetworkCookieParam, 0, len(cookies))
+	for _, c := range cookies {
+		params = append(params, cookieToProto(c))
+	}
+	return p.Inner.SetCookies(params)
+}
+
+func (p RodPage) Storage() (OriginStorage, error) {
+	var s OriginStorage
+	obj, err := p.Inner.Eval(exportStorageJS)
+	if err != nil {
+		return s, err
+	}
+	return s, obj.Value.Unmarshal(&s)
+}
+
+func (p RodPage) SetStorage(s OriginStorage) error {
+	_, err := p.Inner.Eval(importStorageJS, s)
+	return err
+}
 
 type RodElement struct{ Inner *rod.Element }
 
-func (e RodElement) MustClick()            { e.Inner.MustClick() }
-func (e RodElement) ScrollIntoView() error { return e.Inner.ScrollIntoView() }
+func (e RodElement) Click() error {
+	return wrapElement(e.Inner.Click(proto.InputMouseButtonLeft, 1))
+}
+func (e RodElement) ScrollIntoView() error { return wrapElement(e.Inner.ScrollIntoView()) }
 
 func (e RodElement) Screenshot(format proto.PageCaptureScreenshotFormat, quality int) ([]byte, error) {
-	return e.Inner.Screenshot(format, quality)
+	buf, err := e.Inner.Screenshot(format, quality)
+	return buf, wrapElement(err)
+}
+
+func (e RodElement) Box() (*proto.DOMRect, error) {
+	shape, err := e.Inner.Shape()
+	if err != nil {
+		return nil, wrapElement(err)
+	}
+	return shape.Box(), nil
+}
+
+// hitAtJS checks what elementFromPoint returns at (x, y).
+const hitAtJS = `function (x, y) {
+	const t = document.elementFromPoint(x, y);
+	return !!t && (t === this || this.contains(t));
+}`
+
+func (e RodElement) HitAt(pt proto.Point) (bool, error) {
+	obj, err := e.Inner.Eval(hitAtJS, pt.X, pt.Y)
+	if err != nil {
+		return false, wrapElement(err)
+	}
+	return obj.Value.Bool(), nil
+}
+
+func (e RodElement) Text() (string, error) {
+	txt, err := e.Inner.Text()
+	return txt, wrapElement(err)
+}
+
+func (e RodElement) Attribute(name string) (*string, error) {
+	v, err := e.Inner.Attribute(name)
+	return v, wrapElement(err)
+}
+
+func (e RodElement) Elements(selector string) ([]Element, error) {
+	els, err := e.Inner.Elements(selector)
+	if err != nil {
+		return nil, wrapElement(err)
+	}
+	return wrapElements(els), nil
+}
+
+func wrapElements(els rod.Elements) []Element {
+	out := make([]Element, 0, len(els))
+	for _, el := range els {
+		out = append(out, RodElement{Inner: el})
+	}
+	return out
 }
 
 func (e RodElement) EvalBool(js string) (bool, error) {
 	obj, err := e.Inner.Eval(js)
 	if err != nil {
-		return false, err
+		return false, wrapElement(err)
 	}
 	if obj == nil {
 		return false, nil
@@ -72,3 +427,13 @@ func (e RodElement) EvalBool(js string) (bool, error) {
 	}
 	return obj.Value.Bool(), nil
 }
+
+func (e RodElement) QuietFor() (time.Duration, error) {
+	obj, err := e.Inner.Eval(quietForJS)
+	if err != nil {
+		return 0, wrapElement(err)
+	}
+	return time.Duration(obj.Value.Num() * float64(time.Millisecond)), nil
+}
+
+func (e RodElement) ImagesDecoded() (bool, error) { return e.EvalBool(imagesDecodedJS) }
@@ -0,0 +1,68 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func TestScreenshotSectionsWritesEachMatch(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.SetAll("section", fakebrowser.Node{Screenshot: []byte("s1")}, fakebrowser.Node{Screenshot: []byte("s2")})
+	drv, _ := newTestDriver(t, fb)
+
+	dir := t.TempDir()
+	tracePath := filepath.Join(dir, "trace.jsonl")
+	if err := drv.StartTrace(tracePath); err != nil {
+		t.Fatalf("StartTrace returned error: %v", err)
+	}
+	pathFor := func(i int) string { return filepath.Join(dir, "rec", fmt.Sprintf("%d.png", i)) }
+
+	paths, err := drv.ScreenshotSections(context.Background(), "section", pathFor)
+	if err != nil {
+		t.Fatalf("ScreenshotSections returned error: %v", err)
+	}
+	if want := []string{pathFor(1), pathFor(2)}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	if buf, _ := os.ReadFile(paths[1]); string(buf) != "s2" {
+		t.Fatalf("second section = %q", buf)
+	}
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	rp, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	replayFor := func(i int) string { return filepath.Join(dir, "replay", fmt.Sprintf("%d.png", i)) }
+	got, err := rp.ScreenshotSections(context.Background(), "section", replayFor)
+	if err != nil || len(got) != 2 {
+		t.Fatalf("replayed ScreenshotSections = %v, %v", got, err)
+	}
+	if buf, _ := os.ReadFile(got[0]); string(buf) != "s1" {
+		t.Fatalf("replayed first section = %q", buf)
+	}
+}
+
+func TestScreenshotSectionsNoMatch(t *testing.T) {
+	t.Parallel()
+
+	drv, _ := newTestDriver(t, fakebrowser.New())
+	_, err := drv.ScreenshotSections(context.Background(), "section", func(i int) string {
+		return filepath.Join(t.TempDir(), fmt.Sprintf("%d.png", i))
+	})
+	if !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected ErrElementNotFound, got %v", err)
+	}
+}
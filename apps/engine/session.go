@@ -0,0 +1,218 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Session is a browser login for one origin, as kept in Config.SessionFile.
+type Session struct {
+	Origin  string    `json:"origin"` // scheme://host[:port] the storage belongs to
+	SavedAt time.Time `json:"saved_at"`
+	Cookies []Cookie  `json:"cookies,omitempty"`
+	OriginStorage
+}
+
+// OriginStorage is the page-side storage of an origin.
+type OriginStorage struct {
+	LocalStorage map[string]string `json:"local_storage,omitempty"`
+	IndexedDB    []IndexedDBStore  `json:"indexed_db,omitempty"`
+}
+
+func (s OriginStorage) empty() bool { return len(s.LocalStorage) == 0 && len(s.IndexedDB) == 0 }
+
+// IndexedDBStore is one object store with its records. Keys and values are
+// kept as JSON, so records that don't survive JSON.stringify are dropped.
+type IndexedDBStore struct {
+	Database      string            `json:"database"`
+	Version       int               `json:"version"`
+	Store         string            `json:"store"`
+	KeyPath       json.RawMessage   `json:"key_path,omitempty"` // null, a string or an array
+	AutoIncrement bool              `json:"auto_increment,omitempty"`
+	Records       []IndexedDBRecord `json:"records"`
+}
+
+type IndexedDBRecord struct {
+	Key   json.RawMessage `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ExportSession reads the cookies and storage of the open page's origin.
+func (e *Engine) ExportSession() (*Session, error) {
+	if err := e.RequirePage(); err != nil {
+		return nil, err
+	}
+	cookies, err := e.page.Cookies()
+	if err != nil {
+		return nil, fmt.Errorf("read cookies: %w", err)
+	}
+	storage, err := e.page.Storage()
+	if err != nil {
+		return nil, fmt.Errorf("read storage: %w", err)
+	}
+	return &Session{Origin: originOf(e.url), SavedAt: time.Now(), Cookies: cookies, OriginStorage: storage}, nil
+}
+
+// SaveSession exports the session to path, readable by its owner only.
+func (e *Engine) SaveSession(path string) error {
+	s, err := e.ExportSession()
+	if err != nil {
+		return err
+	}
+	buf, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return writePrivate(path, buf)
+}
+
+// LoadSession reads a session saved by SaveSession; a missing file is nil,
+// nil.
+func LoadSession(path string) (*Session, error) {
+	buf, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s Session
+	if err := json.Unmarshal(buf, &s); err != nil {
+		return nil, fmt.Errorf("decode session %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// pendingSession returns Config.SessionFile's session until an open has
+// succeeded with it, and nil afterwards, so a failed first Open restores it
+// again on the retry.
+func (e *Engine) pendingSession() (*Session, error) {
+	if e.cfg.SessionFile == "" || e.sessionLoaded {
+		return nil, nil
+	}
+	s, err := LoadSession(e.cfg.SessionFile)
+	if err != nil {
+		return nil, fmt.Errorf("load session: %w", err)
+	}
+	return s, nil
+}
+
+func originOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// writePrivate replaces path atomically with a 0600 file.
+func writePrivate(path string, buf []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".session-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// exportStorageJS collects localStorage and every IndexedDB object store of
+// the page's origin.
+const exportStorageJS = `async () => {
+	const req = (r) => new Promise((res, rej) => { r.onsuccess = () => res(r.result); r.onerror = () => rej(r.error); });
+	const local = {};
+	for (let i = 0; i < localStorage.length; i++) {
+		const k = localStorage.key(i);
+		local[k] = localStorage.getItem(k);
+	}
+	const stores = [];
+	const dbs = indexedDB.databases ? await indexedDB.databases() : [];
+	for (const info of dbs) {
+		const db = await req(indexedDB.open(info.name));
+		try {
+			for (const name of Array.from(db.objectStoreNames)) {
+				const st = db.transaction(name, "readonly").objectStore(name);
+				const [keys, values] = await Promise.all([req(st.getAllKeys()), req(st.getAll())]);
+				const records = [];
+				keys.forEach((k, i) => {
+					try {
+						records.push({ key: JSON.parse(JSON.stringify(k)), value: JSON.parse(JSON.stringify(values[i])) });
+					} catch (e) {}
+				});
+				stores.push({ database: info.name, version: db.version, store: name, key_path: st.keyPath, auto_increment: st.autoIncrement, records: records });
+			}
+		} finally {
+			db.close();
+		}
+	}
+	return { local_storage: local, indexed_db: stores };
+}`
+
+// importStorageJS writes the storage back. A database the app hasn't
+// created yet is created at the saved version with the saved stores; an
+// existing one keeps its schema and stores it no longer has are skipped.
+const importStorageJS = `async (s) => {
+	const req = (r) => new Promise((res, rej) => { r.onsuccess = () => res(r.result); r.onerror = () => rej(r.error); });
+	for (const [k, v] of Object.entries(s.local_storage || {})) {
+		localStorage.setItem(k, v);
+	}
+	const byDB = {};
+	for (const st of s.indexed_db || []) {
+		(byDB[st.database] = byDB[st.database] || []).push(st);
+	}
+	const existing = indexedDB.databases ? (await indexedDB.databases()).map((d) => d.name) : [];
+	for (const [name, stores] of Object.entries(byDB)) {
+		const fresh = !existing.includes(name);
+		const open = fresh ? indexedDB.open(name, Math.max(1, stores[0].version)) : indexedDB.open(name);
+		if (fresh) {
+			open.onupgradeneeded = () => {
+				for (const st of stores) {
+					if (!open.result.objectStoreNames.contains(st.store)) {
+						open.result.createObjectStore(st.store, { keyPath: st.key_path === undefined ? null : st.key_path, autoIncrement: !!st.auto_increment });
+					}
+				}
+			};
+		}
+		const db = await req(open);
+		try {
+			for (const st of stores) {
+				if (!db.objectStoreNames.contains(st.store)) {
+					continue;
+				}
+				const tx = db.transaction(st.store, "readwrite");
+				const os = tx.objectStore(st.store);
+				for (const r of st.records || []) {
+					if (os.keyPath === null) {
+						os.put(r.value, r.key);
+					} else {
+						os.put(r.value);
+					}
+				}
+				await new Promise((res, rej) => { tx.oncomplete = res; tx.onerror = () => rej(tx.error); });
+			}
+		} finally {
+			db.close();
+		}
+	}
+	return true;
+}`
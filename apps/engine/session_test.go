@@ -0,0 +1,209 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func sessionEngine(fb *fakebrowser.Browser, sessionFile string) (*engine.Driver, *engine.Engine) {
+	cfg := engine.DefaultConfig()
+	cfg.SessionFile = sessionFile
+	eng := engine.NewWithBrowser(cfg, fb)
+	return engine.NewDriver(eng), eng
+}
+
+var testStorage = engine.OriginStorage{
+	LocalStorage: map[string]string{"auth_token": "t0k3n"},
+	IndexedDB: []engine.IndexedDBStore{{
+		Database: "app",
+		Version:  2,
+		Store:    "kv",
+		Records:  []engine.IndexedDBRecord{{Key: json.RawMessage(`"device"`), Value: json.RawMessage(`{"id":"d1"}`)}},
+	}},
+}
+
+func TestEngineSavesSessionOnClose(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	fb := fakebrowser.New()
+	fb.AddCookies(engine.Cookie{Name: "session", Value: "s3cret", Domain: ".example.test"})
+	fb.SetStorage(testStorage)
+	drv, _ := sessionEngine(fb, path)
+
+	if err := drv.Open(context.Background(), "https://example.test/app?x=1"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	drv.MarkLoggedIn(true)
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("session file mode = %v, %v; want 0600", fi.Mode().Perm(), err)
+	}
+	s, err := engine.LoadSession(path)
+	if err != nil {
+		t.Fatalf("LoadSession returned error: %v", err)
+	}
+	if s.Origin != "https://example.test" {
+		t.Fatalf("Origin = %q", s.Origin)
+	}
+	if len(s.Cookies) != 1 || s.Cookies[0].Value != "s3cret" {
+		t.Fatalf("Cookies = %+v", s.Cookies)
+	}
+	if !reflect.DeepEqual(s.OriginStorage, testStorage) {
+		t.Fatalf("storage = %+v, want %+v", s.OriginStorage, testStorage)
+	}
+}
+
+func TestEngineRestoresSessionOnFirstOpen(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	saved := engine.Session{
+		Origin:        "https://example.test",
+		Cookies:       []engine.Cookie{{Name: "session", Value: "s3cret", Domain: ".example.test"}},
+		OriginStorage: testStorage,
+	}
+	buf, _ := json.Marshal(saved)
+	if err := os.WriteFile(path, buf, 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	fb := fakebrowser.New()
+	drv, _ := sessionEngine(fb, path)
+	ctx := context.Background()
+	const url = "https://example.test/app"
+	if err := drv.Open(ctx, url); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	if got := fb.Cookies(); !reflect.DeepEqual(got, saved.Cookies) {
+		t.Fatalf("cookies = %+v, want %+v", got, saved.Cookies)
+	}
+	if got := fb.Storage(); !reflect.DeepEqual(got, testStorage) {
+		t.Fatalf("storage = %+v, want %+v", got, testStorage)
+	}
+	// loaded, then reloaded with the restored storage
+	if got := fb.Opened(); !reflect.DeepEqual(got, []string{url, url}) {
+		t.Fatalf("opened = %v", got)
+	}
+
+	if err := drv.Open(ctx, url); err != nil {
+		t.Fatalf("second Open returned error: %v", err)
+	}
+	if got := len(fb.Opened()); got != 3 {
+		t.Fatalf("second Open restored the session again (%d navigations)", got)
+	}
+}
+
+func TestEngineKeepsSessionFileUnlessLoggedIn(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	good := []byte(`{"origin":"https://example.test","cookies":[{"name":"session","value":"good","domain":".example.test"}]}`)
+	if err := os.WriteFile(path, good, 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	fb := fakebrowser.New()
+	drv, _ := sessionEngine(fb, path)
+	ctx := context.Background()
+	if err := drv.Open(ctx, "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	drv.MarkLoggedIn(true)
+	// A reopen lands on the login screen; nothing confirms it.
+	if err := drv.Open(ctx, "https://example.test/app"); err != nil {
+		t.Fatalf("second Open returned error: %v", err)
+	}
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != string(good) {
+		t.Fatalf("session file overwritten without a confirmed login: %s", got)
+	}
+}
+
+func TestEngineRestoresSessionAgainAfterFailedOpen(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	buf, _ := json.Marshal(engine.Session{
+		Origin:  "https://example.test",
+		Cookies: []engine.Cookie{{Name: "session", Value: "s3cret", Domain: ".example.test"}},
+	})
+	if err := os.WriteFile(path, buf, 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	fb := fakebrowser.New()
+	fb.FailLoads(context.DeadlineExceeded)
+	drv, _ := sessionEngine(fb, path)
+	ctx := context.Background()
+	const url = "https://example.test/app"
+	if err := drv.Open(ctx, url); !errors.Is(err, engine.ErrNavigationTimeout) {
+		t.Fatalf("first Open error = %v, want ErrNavigationTimeout", err)
+	}
+	fb.ClearCookies()
+	if err := drv.Open(ctx, url); err != nil {
+		t.Fatalf("retried Open returned error: %v", err)
+	}
+	if got := fb.Cookies(); len(got) != 1 || got[0].Value != "s3cret" {
+		t.Fatalf("cookies after retry = %+v, want the saved session restored", got)
+	}
+}
+
+func TestEngineRestoresOnlyCookiesOnOtherOrigin(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	buf, _ := json.Marshal(engine.Session{
+		Origin:        "https://other.test",
+		Cookies:       []engine.Cookie{{Name: "session", Value: "s3cret", Domain: ".other.test"}},
+		OriginStorage: testStorage,
+	})
+	if err := os.WriteFile(path, buf, 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	fb := fakebrowser.New()
+	drv, _ := sessionEngine(fb, path)
+	if err := drv.Open(context.Background(), "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if got := len(fb.Cookies()); got != 1 {
+		t.Fatalf("expected the cookie to be restored, got %d", got)
+	}
+	if got := fb.Storage(); got.LocalStorage != nil || got.IndexedDB != nil {
+		t.Fatalf("storage of another origin was restored: %+v", got)
+	}
+	if got := len(fb.Opened()); got != 1 {
+		t.Fatalf("expected no reload, got %d navigations", got)
+	}
+}
+
+func TestEngineOpenWithoutSessionFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "missing", "session.json")
+	fb := fakebrowser.New()
+	drv, _ := sessionEngine(fb, path)
+	if err := drv.Open(context.Background(), "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	drv.MarkLoggedIn(true)
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Close did not create the session file: %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// WaitKind names a wait condition (see Wait).
+type WaitKind string
+
+const (
+	WaitNetworkIdle  WaitKind = "network_idle"
+	WaitDOMStable    WaitKind = "dom_stable"
+	WaitBoxStable    WaitKind = "box_stable"
+	WaitImagesLoaded WaitKind = "images_loaded"
+)
+
+// Wait is one condition for IDriver.WaitFor; build it with NetworkIdle,
+// DOMStable, BoxStable or ImagesLoaded.
+type Wait struct {
+	Kind     WaitKind      `json:"kind"`
+	Selector string        `json:"selector,omitempty"`
+	Quiet    time.Duration `json:"quiet,omitempty"`
+}
+
+// NetworkIdle holds once no request the page sent since it was opened has
+// been in flight for quiet. Images, media, fonts, WebSockets and
+// EventSource streams are ignored; everything else (documents, XHR, fetch,
+// scripts, stylesheets, ...) counts.
+func NetworkIdle(quiet time.Duration) Wait { return Wait{Kind: WaitNetworkIdle, Quiet: quiet} }
+
+// DOMStable holds once nothing in selector's subtree has changed for quiet.
+func DOMStable(selector string, quiet time.Duration) Wait {
+	return Wait{Kind: WaitDOMStable, Selector: selector, Quiet: quiet}
+}
+
+// BoxStable holds once selector's bounding box has not moved or resized for
+// quiet, i.e. its animation has finished.
+func BoxStable(selector string, quiet time.Duration) Wait {
+	return Wait{Kind: WaitBoxStable, Selector: selector, Quiet: quiet}
+}
+
+// ImagesLoaded holds once every shown <img> in selector (or selector
+// itself) has loaded and decoded.
+func ImagesLoaded(selector string) Wait { return Wait{Kind: WaitImagesLoaded, Selector: selector} }
+
+func (w Wait) String() string {
+	if w.Selector == "" {
+		return string(w.Kind)
+	}
+	return fmt.Sprintf("%s(%s)", w.Kind, w.Selector)
+}
+
+// settlePoll is how often element conditions are re-checked.
+const settlePoll = 50 * time.Millisecond
+
+func (d *Driver) WaitFor(ctx context.Context, conds ...Wait) error {
+	start := time.Now()
+	err := d.e.waitFor(ctx, conds)
+	d.tracer.Record(TraceEntry{Op: TraceOpWaitFor, Selectors: waitSelectors(conds), Waits: conds}, start, err)
+	return err
+}
+
+// waitSelectors lists the selectors conds look at, for the trace.
+func waitSelectors(conds []Wait) []string {
+	var sels []string
+	for _, w := range conds {
+		if w.Selector != "" {
+			sels = append(sels, w.Selector)
+		}
+	}
+	return sels
+}
+
+// waitFor checks conds in order, all within Config.SettleTimeout.
+func (e *Engine) waitFor(ctx context.Context, conds []Wait) error {
+	if err := e.RequirePage(); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(e.cfg.SettleTimeout)
+	for _, w := range conds {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var err error
+		switch w.Kind {
+		case WaitNetworkIdle:
+			err = e.page.Timeout(time.Until(deadline)).WaitRequestIdle(w.Quiet)
+		case WaitDOMStable, WaitBoxStable, WaitImagesLoaded:
+			err = e.pollElement(ctx, w, deadline)
+		default:
+			return fmt.Errorf("engine: unknown wait %q", w.Kind)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrWaitTimeout, w, err)
+		}
+	}
+	return nil
+}
+
+// pollElement re-checks an element condition until it holds. The element
+// is looked up on every poll, so a re-rendered node starts over.
+func (e *Engine) pollElement(ctx context.Context, w Wait, deadline time.Time) error {
+	var (
+		lastBox proto.DOMRect
+		stillAt time.Time
+		last    error
+	)
+	for {
+		ok, box, err := e.checkElement(w)
+		switch {
+		case err != nil:
+			last = err
+		case w.Kind == WaitBoxStable:
+			if box != lastBox || stillAt.IsZero() {
+				lastBox, stillAt = box, time.Now()
+			}
+			ok = time.Since(stillAt) >= w.Quiet
+		}
+		if ok {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			if last == nil {
+				last = context.DeadlineExceeded
+			}
+			return last
+		}
+		if err := sleepCtx(ctx, settlePoll); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *Engine) checkElement(w Wait) (ok bool, box proto.DOMRect, err error) {
+	el, err := e.page.Timeout(600 * time.Millisecond).Element(w.Selector)
+	if err != nil {
+		return false, box, err
+	}
+	if el == nil {
+		return false, box, fmt.Errorf("%w: %s", ErrElementNotFound, w.Selector)
+	}
+	switch w.Kind {
+	case WaitDOMStable:
+		quiet, err := el.QuietFor()
+		return err == nil && quiet >= w.Quiet, box, err
+	case WaitBoxStable:
+		b, err := el.Box()
+		if err != nil || b == nil {
+			return false, box, err
+		}
+		return false, *b, nil
+	default:
+		ok, err := el.ImagesDecoded()
+		return ok, box, err
+	}
+}
+
+// quietForJS returns the milliseconds since the last mutation in the
+// element's subtree. The first call starts observing and returns 0.
+const quietForJS = `function () {
+	const now = performance.now();
+	if (!this.__swipeassistQuiet) {
+		const st = (this.__swipeassistQuiet = { last: now });
+		new MutationObserver(() => { st.last = performance.now(); })
+			.observe(this, { subtree: true, childList: true, attributes: true, characterData: true });
+		return 0;
+	}
+	return now - this.__swipeassistQuiet.last;
+}`
+
+// imagesDecodedJS is true once every shown <img> in the element has loaded
+// and can be painted. Hidden images and lazy ones outside the viewport are
+// skipped; they only load once shown or scrolled to.
+const imagesDecodedJS = `async function () {
+	const imgs = this.tagName === "IMG" ? [this] : Array.from(this.querySelectorAll("img"));
+	const shown = imgs.filter((img) => {
+		const r = img.getBoundingClientRect();
+		if (r.width === 0 && r.height === 0) {
+			return false;
+		}
+		return img.loading !== "lazy" || (r.bottom >= 0 && r.top <= innerHeight);
+	});
+	if (shown.some((img) => !img.complete || img.naturalWidth === 0)) {
+		return false;
+	}
+	try {
+		await Promise.all(shown.map((img) => img.decode()));
+	} catch (e) {
+		return false;
+	}
+	return true;
+}`
@@ -0,0 +1,101 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func settleDriver(t *testing.T, fb *fakebrowser.Browser, timeout time.Duration) *engine.Driver {
+	t.Helper()
+
+	cfg := engine.DefaultConfig()
+	cfg.SettleTimeout = timeout
+	drv := engine.NewDriver(engine.NewWithBrowser(cfg, fb))
+	if err := drv.Open(context.Background(), "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	return drv
+}
+
+func TestWaitForNetworkIdle(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	drv := settleDriver(t, fb, 2*time.Second)
+	fb.InFlight(2)
+	time.AfterFunc(100*time.Millisecond, func() { fb.InFlight(0) })
+
+	start := time.Now()
+	if err := drv.WaitFor(context.Background(), engine.NetworkIdle(50*time.Millisecond)); err != nil {
+		t.Fatalf("WaitFor returned error: %v", err)
+	}
+	if took := time.Since(start); took < 150*time.Millisecond {
+		t.Fatalf("WaitFor returned after %s, before the requests finished and went quiet", took)
+	}
+}
+
+func TestWaitForBoxStable(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Show("#photo")
+	drv := settleDriver(t, fb, 2*time.Second)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for x := 0.0; x < 100; x += 20 {
+			fb.Move("#photo", proto.DOMRect{X: x, Y: 10, Width: 200, Height: 300})
+			time.Sleep(30 * time.Millisecond)
+		}
+	}()
+
+	if err := drv.WaitFor(context.Background(), engine.BoxStable("#photo", 100*time.Millisecond)); err != nil {
+		t.Fatalf("WaitFor returned error: %v", err)
+	}
+	select {
+	case <-done:
+	default:
+		t.Fatalf("WaitFor returned while the box was still moving")
+	}
+}
+
+func TestWaitForDOMStableAndImages(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Set("#card", fakebrowser.Node{Visible: true, ImagesLoading: true})
+	drv := settleDriver(t, fb, 2*time.Second)
+	time.AfterFunc(100*time.Millisecond, func() { fb.Set("#card", fakebrowser.Node{Visible: true}) })
+
+	start := time.Now()
+	err := drv.WaitFor(context.Background(), engine.ImagesLoaded("#card"), engine.DOMStable("#card", 80*time.Millisecond))
+	if err != nil {
+		t.Fatalf("WaitFor returned error: %v", err)
+	}
+	if took := time.Since(start); took < 180*time.Millisecond {
+		t.Fatalf("WaitFor returned after %s, before the images loaded and the card went quiet", took)
+	}
+}
+
+func TestWaitForTimesOut(t *testing.T) {
+	t.Parallel()
+
+	fb := fakebrowser.New()
+	fb.Set("#card", fakebrowser.Node{Visible: true, ImagesLoading: true})
+	drv := settleDriver(t, fb, 150*time.Millisecond)
+
+	err := drv.WaitFor(context.Background(), engine.ImagesLoaded("#card"))
+	if !errors.Is(err, engine.ErrWaitTimeout) {
+		t.Fatalf("expected ErrWaitTimeout, got %v", err)
+	}
+	err = drv.WaitFor(context.Background(), engine.BoxStable("#missing", time.Millisecond))
+	if !errors.Is(err, engine.ErrWaitTimeout) || !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected ErrWaitTimeout wrapping ErrElementNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type TraceOp string
+
+const (
+	TraceOpOpen               TraceOp = "open"
+	TraceOpScreenshot         TraceOp = "screenshot"
+	TraceOpScreenshotElement  TraceOp = "screenshot_element"
+	TraceOpWaitAnyVisible     TraceOp = "wait_any_visible"
+	TraceOpIsVisible          TraceOp = "is_visible"
+	TraceOpClick              TraceOp = "click_by_selectors"
+	TraceOpTypeText           TraceOp = "type_text"
+	TraceOpTypeSecret         TraceOp = "type_secret"
+	TraceOpTexts              TraceOp = "texts"
+	TraceOpAttribute          TraceOp = "attribute"
+	TraceOpRecords            TraceOp = "records"
+	TraceOpWatchResponses     TraceOp = "watch_responses"
+	TraceOpScreenshotSections TraceOp = "screenshot_sections"
+	TraceOpCookies            TraceOp = "cookies"
+	TraceOpSetCookies         TraceOp = "set_cookies"
+	TraceOpAlive              TraceOp = "alive"
+	TraceOpReconnect          TraceOp = "reconnect"
+	TraceOpWaitFor            TraceOp = "wait_for"
+	// TraceOpResponse is a network response delivered to a watch, not a call.
+	TraceOpResponse TraceOp = "response"
+)
+
+// TraceEntry is one recorded driver call.
+type TraceEntry struct {
+	Seq       int      `json:"seq"`
+	Op        TraceOp  `json:"op"`
+	URL       string   `json:"url,omitempty"`
+	Selectors []string `json:"selectors,omitempty"`
+	Matched   string   `json:"matched,omitempty"` // selector that resolved, if any
+	Visible   *bool    `json:"visible,omitempty"` // IsVisible result
+	// DOM reads: request (Attr, Fields) and result (Value, Texts, Records).
+	Attr    string              `json:"attr,omitempty"`
+	Fields  map[string]string   `json:"fields,omitempty"`
+	Value   *string             `json:"value,omitempty"`
+	Texts   []string            `json:"texts,omitempty"`
+	Records []map[string]string `json:"records,omitempty"`
+	// Waits are the conditions of a WaitFor call.
+	Waits []Wait `json:"waits,omitempty"`
+	// Input is the text typed by TypeText; TypeSecret leaves it out.
+	Input string `json:"input,omitempty"`
+	// Cookies read or set, without their values.
+	Cookies []Cookie `json:"cookies,omitempty"`
+	// Network responses: Watch is the 1-based WatchResponses call it was
+	// delivered to.
+	Watch     int           `json:"watch,omitempty"`
+	Status    int           `json:"status,omitempty"`
+	MIMEType  string        `json:"mime_type,omitempty"`
+	Body      string        `json:"body,omitempty"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+	// ErrorKind names the typed engine error (see errorKinds) so a replay can
+	// hand back an error that still matches errors.Is.
+	ErrorKind string `json:"error_kind,omitempty"`
+	// Screenshot is the captured image, copied next to the trace and stored
+	// relative to the trace file's directory.
+	Screenshot string `json:"screenshot,omitempty"`
+	// Screenshots holds the images of multi-shot calls, in order.
+	Screenshots []string `json:"screenshots,omitempty"`
+}
+
+var errorKinds = []struct {
+	kind string
+	err  error
+}{
+	{"connection_failed", ErrConnectionFailed},
+	{"connection_lost", ErrConnectionLost},
+	{"navigation_timeout", ErrNavigationTimeout},
+	{"element_detached", ErrElementDetached},
+	{"element_not_found", ErrElementNotFound},
+	{"page_not_open", ErrPageNotOpen},
+	{"wait_timeout", ErrWaitTimeout},
+	{"context_canceled", context.Canceled},
+	{"deadline_exceeded", context.DeadlineExceeded},
+}
+
+func errorKind(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			return k.kind
+		}
+	}
+	return "other"
+}
+
+// replayedError reproduces a recorded error message and its typed cause.
+type replayedError struct {
+	msg   string
+	cause error
+}
+
+func (e *replayedError) Error() string { return e.msg }
+func (e *replayedError) Unwrap() error { return e.cause }
+
+func errorFromEntry(en TraceEntry) error {
+	if en.Error == "" && en.ErrorKind == "" {
+		return nil
+	}
+	var cause error
+	for _, k := range errorKinds {
+		if k.kind == en.ErrorKind {
+			cause = k.err
+			break
+		}
+	}
+	return &replayedError{msg: en.Error, cause: cause}
+}
+
+// Tracer appends TraceEntry records as JSON lines, flushing after each one so
+// a crashed run still leaves a usable trace. Screenshots are copied into
+// "<trace>.shots/". A nil *Tracer records nothing.
+type Tracer struct {
+	mu       sync.Mutex
+	f        *os.File
+	w        *bufio.Writer
+	dir      string
+	shotsDir string
+	seq      int
+}
+
+func NewTracer(path string) (*Tracer, error) {
+	if err := ensureDir(path); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Tracer{
+		f:        f,
+		w:        bufio.NewWriter(f),
+		dir:      filepath.Dir(path),
+		shotsDir: path + ".shots",
+	}, nil
+}
+
+// Record stamps timing and error details onto en and appends it.
+func (t *Tracer) Record(en TraceEntry, start time.Time, err error) {
+	t.RecordScreenshot(en, "", start, err)
+}
+
+// RecordScreenshot is Record plus a copy of the captured file at shotPath
+// (skipped when err is set or shotPath is empty).
+func (t *Tracer) RecordScreenshot(en TraceEntry, shotPath string, start time.Time, err error) {
+	var shots []string
+	if shotPath != "" {
+		shots = []string{shotPath}
+	}
+	t.record(en, shots, false, start, err)
+}
+
+// RecordScreenshots is Record plus copies of every file in shotPaths, kept
+// even when err is set (they were captured before it).
+func (t *Tracer) RecordScreenshots(en TraceEntry, shotPaths []string, start time.Time, err error) {
+	t.record(en, shotPaths, true, start, err)
+}
+
+func (t *Tracer) record(en TraceEntry, shots []string, multi bool, start time.Time, err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.seq++
+	en.Seq = t.seq
+	en.StartedAt = start
+	en.Duration = time.Since(start)
+	if err != nil {
+		en.Error = err.Error()
+		en.ErrorKind = errorKind(err)
+	}
+	if err == nil || multi {
+		for i, shot := range shots {
+			n := 0
+			if multi {
+				n = i + 1
+			}
+			ref, cerr := t.copyShot(shot, n)
+			if cerr != nil {
+				if err == nil {
+					en.Error = fmt.Sprintf("trace: copy screenshot: %v", cerr)
+					en.ErrorKind = ""
+				}
+				break
+			}
+			if multi {
+				en.Screenshots = append(en.Screenshots, ref)
+			} else {
+				en.Screenshot = ref
+			}
+		}
+	}
+
+	b, merr := json.Marshal(en)
+	if merr != nil {
+		return
+	}
+	_, _ = t.w.Write(append(b, '\n'))
+	_ = t.w.Flush()
+}
+
+// copyShot stores src as "<seq>.ext", or "<seq>_<n>.ext" for shot n of a
+// multi-shot call.
+func (t *Tracer) copyShot(src string, n int) (string, error) {
+	buf, err := os.ReadFile(src)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%06d%s", t.seq, filepath.Ext(src))
+	if n > 0 {
+		name = fmt.Sprintf("%06d_%02d%s", t.seq, n, filepath.Ext(src))
+	}
+	dst := filepath.Join(t.shotsDir, name)
+	if err := ensureDir(dst); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(dst, buf, 0o644); err != nil {
+		return "", err
+	}
+	return filepath.Rel(t.dir, dst)
+}
+
+func (t *Tracer) Close() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.w.Flush(); err != nil {
+		_ = t.f.Close()
+		return err
+	}
+	return t.f.Close()
+}
+
+// LoadTrace reads a trace written by Tracer.
+func LoadTrace(path string) ([]TraceEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []TraceEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var en TraceEntry
+		if err := json.Unmarshal(sc.Bytes(), &en); err != nil {
+			return nil, fmt.Errorf("trace %s line %d: %w", path, line, err)
+		}
+		out = append(out, en)
+	}
+	return out, sc.Err()
+}
@@ -0,0 +1,158 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func recordSession(t *testing.T, tracePath, shotPath string) {
+	t.Helper()
+
+	fb := fakebrowser.New()
+	fb.Show("#ready", "#like")
+	fb.Set("#album", fakebrowser.Node{Visible: true, Screenshot: []byte("album-1")})
+
+	drv, _ := newTestDriver(t, fb)
+	if err := drv.StartTrace(tracePath); err != nil {
+		t.Fatalf("StartTrace returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := drv.Open(ctx, "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if err := drv.WaitAnyVisible(ctx, []string{"#missing", "#ready"}); err != nil {
+		t.Fatalf("WaitAnyVisible returned error: %v", err)
+	}
+	if ok, _ := drv.IsVisible(ctx, []string{"#disabled"}); ok {
+		t.Fatalf("expected #disabled to be hidden")
+	}
+	if err := drv.ScreenshotElement(ctx, "#album", shotPath); err != nil {
+		t.Fatalf("ScreenshotElement returned error: %v", err)
+	}
+	if err := drv.ClickBySelectors(ctx, []string{"#nope"}); !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected ErrElementNotFound, got %v", err)
+	}
+	if err := drv.ClickBySelectors(ctx, []string{"#like"}); err != nil {
+		t.Fatalf("ClickBySelectors returned error: %v", err)
+	}
+	if err := drv.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+}
+
+func TestTraceRecordsCalls(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tracePath := filepath.Join(dir, "trace.jsonl")
+	recordSession(t, tracePath, filepath.Join(dir, "shot.png"))
+
+	entries, err := engine.LoadTrace(tracePath)
+	if err != nil {
+		t.Fatalf("LoadTrace returned error: %v", err)
+	}
+	wantOps := []engine.TraceOp{
+		engine.TraceOpOpen,
+		engine.TraceOpWaitAnyVisible,
+		engine.TraceOpIsVisible,
+		engine.TraceOpScreenshotElement,
+		engine.TraceOpClick,
+		engine.TraceOpClick,
+	}
+	if len(entries) != len(wantOps) {
+		t.Fatalf("expected %d entries, got %d: %#v", len(wantOps), len(entries), entries)
+	}
+	for i, op := range wantOps {
+		if entries[i].Op != op || entries[i].Seq != i+1 {
+			t.Fatalf("entry %d: got op=%s seq=%d, want op=%s", i, entries[i].Op, entries[i].Seq, op)
+		}
+	}
+	if entries[1].Matched != "#ready" {
+		t.Fatalf("expected wait to record matched selector, got %q", entries[1].Matched)
+	}
+	if entries[4].ErrorKind != "element_not_found" || entries[4].Error == "" {
+		t.Fatalf("expected typed error on failed click, got %#v", entries[4])
+	}
+	if entries[5].Matched != "#like" || entries[5].Duration <= 0 {
+		t.Fatalf("unexpected click entry: %#v", entries[5])
+	}
+
+	shot, err := os.ReadFile(filepath.Join(dir, entries[3].Screenshot))
+	if err != nil {
+		t.Fatalf("read traced screenshot: %v", err)
+	}
+	if string(shot) != "album-1" {
+		t.Fatalf("unexpected traced screenshot: %q", shot)
+	}
+}
+
+func TestReplayDriverReproducesSession(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tracePath := filepath.Join(dir, "trace.jsonl")
+	recordSession(t, tracePath, filepath.Join(dir, "shot.png"))
+
+	r, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := r.Open(ctx, "https://example.test/app"); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if err := r.WaitAnyVisible(ctx, []string{"#missing", "#ready"}); err != nil {
+		t.Fatalf("WaitAnyVisible returned error: %v", err)
+	}
+	if ok, err := r.IsVisible(ctx, []string{"#disabled"}); ok || err != nil {
+		t.Fatalf("IsVisible = %v, %v; want false, nil", ok, err)
+	}
+	out := filepath.Join(dir, "replayed", "shot.png")
+	if err := r.ScreenshotElement(ctx, "#album", out); err != nil {
+		t.Fatalf("ScreenshotElement returned error: %v", err)
+	}
+	if b, _ := os.ReadFile(out); string(b) != "album-1" {
+		t.Fatalf("unexpected replayed screenshot: %q", b)
+	}
+	if err := r.ClickBySelectors(ctx, []string{"#nope"}); !errors.Is(err, engine.ErrElementNotFound) {
+		t.Fatalf("expected replayed ErrElementNotFound, got %v", err)
+	}
+	if err := r.ClickBySelectors(ctx, []string{"#like"}); err != nil {
+		t.Fatalf("ClickBySelectors returned error: %v", err)
+	}
+	if r.Remaining() != 0 {
+		t.Fatalf("expected trace to be fully consumed, %d left", r.Remaining())
+	}
+	if err := r.ClickBySelectors(ctx, []string{"#like"}); !errors.Is(err, engine.ErrTraceMismatch) {
+		t.Fatalf("expected ErrTraceMismatch when exhausted, got %v", err)
+	}
+}
+
+func TestReplayDriverDetectsDivergence(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tracePath := filepath.Join(dir, "trace.jsonl")
+	recordSession(t, tracePath, filepath.Join(dir, "shot.png"))
+
+	r, err := engine.NewReplayDriver(tracePath)
+	if err != nil {
+		t.Fatalf("NewReplayDriver returned error: %v", err)
+	}
+	ctx := context.Background()
+	if err := r.ClickBySelectors(ctx, []string{"#like"}); !errors.Is(err, engine.ErrTraceMismatch) {
+		t.Fatalf("expected op mismatch, got %v", err)
+	}
+	_ = r.Open(ctx, "x")
+	if err := r.WaitAnyVisible(ctx, []string{"#other"}); !errors.Is(err, engine.ErrTraceMismatch) {
+		t.Fatalf("expected selector mismatch, got %v", err)
+	}
+}
@@ -11,6 +11,54 @@ type IDriver interface {
 	// IsVisible returns true if any selector matches a visible element right now (no retries).
 	IsVisible(ctx context.Context, selectors []string) (bool, error)
 	ClickBySelectors(ctx context.Context, selectors []string) error
+	// TypeText clicks the first visible selector to focus it and types text
+	// into it.
+	TypeText(ctx context.Context, selectors []string, text string) error
+	// TypeSecret types like TypeText without recording the text in a trace;
+	// use it for credentials and login codes.
+	TypeSecret(ctx context.Context, selectors []string, text string) error
 
-	Close()
+	// Texts returns the trimmed text of every element matching selector, in
+	// document order, skipping empty ones. No match is not an error.
+	Texts(ctx context.Context, selector string) ([]string, error)
+	// Attribute reads an attribute of the first element matching selector;
+	// ok is false when there is no such element or attribute.
+	Attribute(ctx context.Context, selector, name string) (value string, ok bool, err error)
+	// Records reads one map per element matching selector. Each field value is
+	// a descendant selector whose trimmed text is read, or "@name" for an
+	// attribute of the element itself; missing fields map to "".
+	Records(ctx context.Context, selector string, fields map[string]string) ([]map[string]string, error)
+
+	// WaitFor waits until each condition holds, in order, within
+	// Config.SettleTimeout; a condition that never holds fails with
+	// ErrWaitTimeout. Element conditions need the element to exist.
+	WaitFor(ctx context.Context, conds ...Wait) error
+
+	// ScreenshotSections scrolls to each element matching selector in document
+	// order and writes its screenshot to pathFor(i) (i is 1-based). It returns
+	// the paths written, including those before a failure.
+	ScreenshotSections(ctx context.Context, selector string, pathFor func(i int) string) ([]string, error)
+
+	// WatchResponses calls fn for every XHR/fetch response whose URL matches
+	// one of the glob patterns ('*' = any run of characters), until stop is
+	// called. Watches registered before Open also see the initial load.
+	WatchResponses(ctx context.Context, patterns []string, fn func(NetworkResponse)) (stop func(), err error)
+
+	// Cookies reads the cookies of the open page's URL; SetCookies stores
+	// cookies in the browser (each carries its own domain).
+	Cookies(ctx context.Context) ([]Cookie, error)
+	SetCookies(ctx context.Context, cookies []Cookie) error
+
+	// Alive returns an error wrapping ErrConnectionLost when the browser
+	// connection or the open tab is gone. Reconnect then connects again,
+	// relaunching a browser the engine started; Open the page afterwards.
+	Alive(ctx context.Context) error
+	Reconnect(ctx context.Context) error
+
+	// MarkLoggedIn tells the driver whether the open page shows the app
+	// logged in; Close only saves Config.SessionFile after a true. Open
+	// clears it.
+	MarkLoggedIn(loggedIn bool)
+
+	Close() error
 }
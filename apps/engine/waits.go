@@ -7,7 +7,9 @@ import (
 )
 
 func (e *Engine) findFirstVisible(ctx context.Context, selectors []string, timeout time.Duration) (Element, string, error) {
-	e.MustHavePage()
+	if err := e.RequirePage(); err != nil {
+		return nil, "", err
+	}
 
 	deadline := time.Now().Add(timeout)
 
@@ -35,5 +37,5 @@ func (e *Engine) findFirstVisible(ctx context.Context, selectors []string, timeo
 		}
 	}
 
-	return nil, "", fmt.Errorf("timeout waiting for any visible selector: %v", selectors)
+	return nil, "", fmt.Errorf("%w: timeout waiting for any visible selector: %v", ErrElementNotFound, selectors)
 }
\ No newline at end of file
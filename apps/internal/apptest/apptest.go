@@ -0,0 +1,29 @@
+// Package apptest sets up the driver the app adapter tests run against.
+package apptest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+// NewDriver returns a driver over fb with short timeouts and one attempt per
+// step, already opened at url.
+func NewDriver(t testing.TB, fb *fakebrowser.Browser, url string) engine.IDriver {
+	t.Helper()
+
+	cfg := engine.DefaultConfig()
+	cfg.StepTimeout = 200 * time.Millisecond
+	cfg.RetryAttempts = 1
+	cfg.RetryDelay = time.Millisecond
+	cfg.Human = engine.HumanConfig{Enabled: true, MinMoveSteps: 4, MaxMoveSteps: 8, CurveSpread: 0.25, ClickInset: 0.2, ScrollJitter: 10}
+
+	drv := engine.NewDriver(engine.NewWithBrowser(cfg, fb))
+	if err := drv.Open(context.Background(), url); err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	return drv
+}
@@ -0,0 +1,124 @@
+// Package cardkit holds the card-reading helpers the app adapters share:
+// reading text and badges off the card on screen and turning what it shows
+// into a profile key.
+package cardkit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+var (
+	digits    = regexp.MustCompile(`\d+`)
+	keyUnsafe = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+)
+
+// FirstText returns the first text matched by selector, without waiting; no
+// match and an empty selector read as "".
+func FirstText(ctx context.Context, d engine.IDriver, selector string) (string, error) {
+	if selector == "" {
+		return "", nil
+	}
+	texts, err := d.Texts(ctx, selector)
+	if err != nil || len(texts) == 0 {
+		return "", err
+	}
+	return texts[0], nil
+}
+
+// Present reports whether selector matches anything, without waiting; badges
+// are often icons with no text. An empty selector is never present.
+func Present(ctx context.Context, d engine.IDriver, selector string) (bool, error) {
+	if selector == "" {
+		return false, nil
+	}
+	recs, err := d.Records(ctx, selector, nil)
+	return len(recs) > 0, err
+}
+
+// Digits returns the first run of digits in s ("29" from "29 years"), or "".
+func Digits(s string) string { return digits.FindString(s) }
+
+// StripQuery drops the query string and fragment from a URL.
+func StripQuery(u string) string {
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		return u[:i]
+	}
+	return u
+}
+
+// Key returns prefix+id with id made safe to use in file names.
+func Key(prefix, id string) string {
+	return prefix + keyUnsafe.ReplaceAllString(id, "-")
+}
+
+// HashKey keys a card with no id by a hash of its name, age and photo URL.
+func HashKey(prefix, name, age, photo string) string {
+	sum := sha256.Sum256([]byte(name + "\x00" + age + "\x00" + photo))
+	return prefix + "h" + hex.EncodeToString(sum[:8])
+}
+
+// Fields are the selectors for the facts a card shows. Empty selectors are
+// skipped and leave the fact zero.
+type Fields struct {
+	Name, Age, Distance, Location, Job, Education string
+	Verified, RecentlyActive, Premium             string
+}
+
+// Read reads the card's facts. Only a missing name is an error: unidentified,
+// which should wrap domain.ErrProfileUnidentified.
+func Read(ctx context.Context, d engine.IDriver, f Fields, unidentified error) (*domain.ProfileCard, error) {
+	card := &domain.ProfileCard{}
+
+	texts := []struct {
+		selector string
+		dst      *string
+	}{
+		{f.Name, &card.Name},
+		{f.Distance, &card.Distance},
+		{f.Location, &card.Location},
+		{f.Job, &card.Job},
+		{f.Education, &card.Education},
+	}
+	for _, t := range texts {
+		v, err := FirstText(ctx, d, t.selector)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", t.selector, err)
+		}
+		*t.dst = v
+	}
+	if card.Name == "" {
+		return nil, unidentified
+	}
+
+	ageText, err := FirstText(ctx, d, f.Age)
+	if err != nil {
+		return nil, fmt.Errorf("read age: %w", err)
+	}
+	card.Age, _ = strconv.Atoi(Digits(ageText))
+
+	badges := []struct {
+		selector string
+		dst      *bool
+	}{
+		{f.Verified, &card.Verified},
+		{f.RecentlyActive, &card.RecentlyActive},
+		{f.Premium, &card.Premium},
+	}
+	for _, b := range badges {
+		v, err := Present(ctx, d, b.selector)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", b.selector, err)
+		}
+		*b.dst = v
+	}
+	return card, nil
+}
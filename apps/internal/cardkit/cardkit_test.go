@@ -0,0 +1,21 @@
+package cardkit
+
+import "testing"
+
+func TestKeys(t *testing.T) {
+	t.Parallel()
+
+	if got := Key("bumble_", "a b/c"); got != "bumble_a-b-c" {
+		t.Fatalf("Key = %q", got)
+	}
+	if got := Digits("29 years"); got != "29" {
+		t.Fatalf("Digits = %q", got)
+	}
+	if got := StripQuery("https://img.test/p.jpg?w=1#x"); got != "https://img.test/p.jpg" {
+		t.Fatalf("StripQuery = %q", got)
+	}
+	a := HashKey("x_", "Ana", "29", "https://img.test/p.jpg")
+	if a != HashKey("x_", "Ana", "29", "https://img.test/p.jpg") || a == HashKey("x_", "Ana", "30", "https://img.test/p.jpg") {
+		t.Fatalf("HashKey not stable per name, age and photo: %q", a)
+	}
+}
@@ -0,0 +1,103 @@
+package apps
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// PromptOTP asks for the login code on w and reads it as one line from r.
+// When ctx ends first the pending read is abandoned and its line is lost.
+func PromptOTP(r io.Reader, w io.Writer) domain.OTPFunc {
+	br := bufio.NewReader(r)
+	return func(ctx context.Context) (string, error) {
+		fmt.Fprint(w, "Enter the login code the app sent you: ")
+		type result struct {
+			line string
+			err  error
+		}
+		done := make(chan result, 1)
+		go func() {
+			line, err := br.ReadString('\n')
+			if err == io.EOF && line != "" {
+				err = nil
+			}
+			done <- result{line, err}
+		}()
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case res := <-done:
+			if res.err != nil {
+				return "", fmt.Errorf("read login code: %w", res.err)
+			}
+			code := strings.TrimSpace(res.line)
+			if code == "" {
+				return "", errors.New("read login code: empty line")
+			}
+			return code, nil
+		}
+	}
+}
+
+// FileOTP waits for the login code to be written to path, checking every
+// poll. Files older than the call are ignored and the file is removed once
+// read, so a code is never used twice.
+func FileOTP(path string, poll time.Duration) domain.OTPFunc {
+	if poll <= 0 {
+		poll = time.Second
+	}
+	return func(ctx context.Context) (string, error) {
+		// mtimes can be as coarse as a second
+		since := time.Now().Truncate(time.Second)
+		t := time.NewTicker(poll)
+		defer t.Stop()
+		for {
+			code, err := readFreshCode(path, since)
+			if err != nil {
+				return "", err
+			}
+			if code != "" {
+				return code, nil
+			}
+			select {
+			case <-ctx.Done():
+				return "", fmt.Errorf("wait for login code in %s: %w", path, ctx.Err())
+			case <-t.C:
+			}
+		}
+	}
+}
+
+func readFreshCode(path string, since time.Time) (string, error) {
+	fi, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if fi.ModTime().Before(since) {
+		return "", nil
+	}
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	code := strings.TrimSpace(string(buf))
+	if code == "" {
+		return "", nil // still being written
+	}
+	if err := os.Remove(path); err != nil {
+		return "", err
+	}
+	return code, nil
+}
@@ -0,0 +1,73 @@
+package apps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPromptOTPReadsOneLinePerCall(t *testing.T) {
+	t.Parallel()
+
+	var prompt bytes.Buffer
+	otp := PromptOTP(strings.NewReader(" 123456 \n654321"), &prompt)
+
+	for _, want := range []string{"123456", "654321"} {
+		got, err := otp(context.Background())
+		if err != nil {
+			t.Fatalf("otp returned error: %v", err)
+		}
+		if got != want {
+			t.Fatalf("otp = %q, want %q", got, want)
+		}
+	}
+	if !strings.Contains(prompt.String(), "login code") {
+		t.Fatalf("no prompt written: %q", prompt.String())
+	}
+}
+
+func TestFileOTPWaitsForFreshCode(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "otp.txt")
+	stale := time.Now().Add(-time.Hour)
+	if err := os.WriteFile(path, []byte("111111\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	if err := os.Chtimes(path, stale, stale); err != nil {
+		t.Fatalf("Chtimes returned error: %v", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = os.WriteFile(path, []byte("222222\n"), 0o600)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	got, err := FileOTP(path, 10*time.Millisecond)(ctx)
+	if err != nil {
+		t.Fatalf("otp returned error: %v", err)
+	}
+	if got != "222222" {
+		t.Fatalf("otp = %q, want the fresh code", got)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("code file left behind: %v", err)
+	}
+}
+
+func TestFileOTPHonoursContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err := FileOTP(filepath.Join(t.TempDir(), "otp.txt"), 5*time.Millisecond)(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+}
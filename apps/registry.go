@@ -1,12 +1,79 @@
 package apps
 
 import (
+	"errors"
+	"fmt"
+	"log"
+
 	"github.com/vd09-projects/swipeassist/apps/bumble"
+	"github.com/vd09-projects/swipeassist/apps/declarative"
+	"github.com/vd09-projects/swipeassist/apps/tinder"
 	"github.com/vd09-projects/swipeassist/domain"
 )
 
+// DefaultAdapterDir holds the declarative adapter specs (see package
+// declarative) loaded at startup.
+const DefaultAdapterDir = "input/adapters"
+
+// GetAdapterRegistry returns the built-in adapters plus the specs under
+// DefaultAdapterDir. Specs that fail to load are logged and left out.
 func GetAdapterRegistry() map[domain.AppName]Adapter {
-	return map[domain.AppName]Adapter{
+	reg, err := LoadAdapterRegistry(DefaultAdapterDir)
+	if err != nil {
+		log.Printf("apps: %v", err)
+	}
+	return reg
+}
+
+// specConfigurable is implemented by built-in adapters that take their
+// selectors from a declarative spec for their app.
+type specConfigurable interface {
+	ApplySpec(declarative.Spec) error
+}
+
+// LoadAdapterRegistry returns the built-in adapters plus one declarative
+// adapter per spec in dir. A spec for a built-in app sets that adapter's
+// selectors instead. Specs that fail to load are left out and reported in
+// the error (see specError), as are specs a built-in rejects, which must
+// leave the adapter's defaults untouched; everything else is still returned.
+func LoadAdapterRegistry(dir string) (map[domain.AppName]Adapter, error) {
+	reg := map[domain.AppName]Adapter{
 		domain.Bumble: bumble.NewAdapterFromDefaults(),
+		domain.Tinder: tinder.NewAdapterFromDefaults(),
+	}
+	specs, err := declarative.LoadDir(dir)
+	errs := []error{err}
+	for _, s := range specs {
+		ad, ok := reg[s.App].(specConfigurable)
+		if !ok {
+			reg[s.App] = declarative.New(s)
+			continue
+		}
+		if err := ad.ApplySpec(s); err != nil {
+			errs = append(errs, &declarative.SpecError{Path: s.Path, App: s.App, Err: err})
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return reg, fmt.Errorf("load adapter specs: %w", err)
+	}
+	return reg, nil
+}
+
+// specError returns the load error of a spec for app, if err has one.
+func specError(err error, app domain.AppName) error {
+	var se *declarative.SpecError
+	if errors.As(err, &se) && se.App == app {
+		return se
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if found := specError(e, app); found != nil {
+				return found
+			}
+		}
+	case interface{ Unwrap() error }:
+		return specError(u.Unwrap(), app)
 	}
+	return nil
 }
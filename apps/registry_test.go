@@ -0,0 +1,108 @@
+package apps
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/bumble"
+	"github.com/vd09-projects/swipeassist/apps/tinder"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func TestLoadAdapterRegistryIncludesShippedSpecs(t *testing.T) {
+	t.Parallel()
+
+	reg, err := LoadAdapterRegistry("../" + DefaultAdapterDir)
+	if err != nil {
+		t.Fatalf("LoadAdapterRegistry returned error: %v", err)
+	}
+	for _, app := range []domain.AppName{domain.Bumble, domain.Tinder} {
+		if _, ok := reg[app]; !ok {
+			t.Fatalf("built-in %s adapter missing", app)
+		}
+	}
+	ad, ok := reg[domain.Bumble].(*bumble.Adapter)
+	if !ok {
+		t.Fatalf("BUMBLE spec replaced the built-in adapter: %#v", reg[domain.Bumble])
+	}
+	if def := bumble.NewAdapterFromDefaults(); !reflect.DeepEqual(ad.S, def.S) || ad.EntryURL != def.EntryURL {
+		t.Fatalf("shipped BUMBLE spec drifted from the built-in selectors:\n got %+v\nwant %+v", ad.S, def.S)
+	}
+}
+
+func TestLoadAdapterRegistryAppliesSpecToBuiltin(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	spec := `
+app: bumble
+entry_url: https://bumble.test/app
+ready_hints: ["main"]
+next_media: {click: [".next"]}
+actions: {LIKE: [".like-v2"]}
+media_capture: ".album"
+card: {name: ".name-v2"}
+`
+	if err := os.WriteFile(filepath.Join(dir, "bumble.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("app: demo\n"), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	reg, err := LoadAdapterRegistry(dir)
+	if err == nil {
+		t.Fatal("broken spec not reported")
+	}
+	ad, ok := reg[domain.Bumble].(*bumble.Adapter)
+	if !ok {
+		t.Fatalf("BUMBLE adapter = %#v, want the built-in", reg[domain.Bumble])
+	}
+	def := bumble.DefaultSelectors()
+	if !reflect.DeepEqual(ad.S.Like, []string{".like-v2"}) || ad.S.CardName != ".name-v2" || ad.EntryURL != "https://bumble.test/app" {
+		t.Fatalf("spec selectors not applied: %+v", ad.S)
+	}
+	if !reflect.DeepEqual(ad.S.Pass, def.Pass) || ad.S.CardAge != def.CardAge {
+		t.Fatalf("selectors the spec leaves out lost their defaults: %+v", ad.S)
+	}
+
+	if specError(err, domain.Bumble) != nil {
+		t.Fatalf("BUMBLE blamed for another app's spec: %v", err)
+	}
+	if serr := specError(err, "DEMO"); serr == nil || !strings.Contains(serr.Error(), "missing") {
+		t.Fatalf("specError(DEMO) = %v", serr)
+	}
+}
+
+func TestLoadAdapterRegistryRejectsUnsupportedTinderSpec(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	spec := `
+app: tinder
+entry_url: https://tinder.test/app
+ready_hints: ["main"]
+next_media: {click: [".next"], disabled: [".next[disabled]"]}
+actions: {LIKE: [".like-v2"]}
+media_capture: ".album"
+card: {name: ".name-v2"}
+`
+	if err := os.WriteFile(filepath.Join(dir, "tinder.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	reg, err := LoadAdapterRegistry(dir)
+	if serr := specError(err, domain.Tinder); serr == nil || !strings.Contains(serr.Error(), "next_media.disabled") {
+		t.Fatalf("specError(TINDER) = %v", serr)
+	}
+	ad, ok := reg[domain.Tinder].(*tinder.Adapter)
+	if !ok {
+		t.Fatalf("TINDER adapter = %#v, want the built-in", reg[domain.Tinder])
+	}
+	if def := tinder.NewAdapterFromDefaults(); !reflect.DeepEqual(ad.S, def.S) || ad.EntryURL != def.EntryURL {
+		t.Fatalf("rejected spec changed the TINDER defaults: %+v", ad.S)
+	}
+}
@@ -0,0 +1,97 @@
+package tinder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// ErrLastPhoto is returned by NextMedia when the album shows its last photo.
+var ErrLastPhoto = errors.New("tinder: already on the last photo")
+
+type Adapter struct {
+	S        Selectors
+	EntryURL string
+
+	// SettleQuiet is how long the network and the album must stay still to
+	// count as settled (see WaitSettled).
+	SettleQuiet time.Duration
+
+	mu       sync.Mutex
+	recs     map[string]Rec
+	recOrder []string
+
+	now func() time.Time // ages of collected recs are computed on this clock
+}
+
+func NewAdapterFromDefaults() *Adapter {
+	return &Adapter{
+		S:           DefaultSelectors(),
+		EntryURL:    "https://tinder.com/app/recs",
+		SettleQuiet: 300 * time.Millisecond,
+		recs:        map[string]Rec{},
+		now:         time.Now,
+	}
+}
+
+func (a *Adapter) Name() string { return "tinder" }
+
+func (a *Adapter) DefaultEntryURL() string { return a.EntryURL }
+
+func (a *Adapter) WaitReady(ctx context.Context, d engine.IDriver) error {
+	return d.WaitAnyVisible(ctx, a.S.ReadyHints)
+}
+
+// NextMedia advances the album. Tinder keeps the next-photo control enabled
+// on the last photo, so the selected dot decides whether there is one.
+func (a *Adapter) NextMedia(ctx context.Context, d engine.IDriver) error {
+	dots, err := d.Records(ctx, a.S.AlbumDots, map[string]string{"selected": "@" + a.S.AlbumDotSelected})
+	if err != nil {
+		return err
+	}
+	if len(dots) < 2 || dots[len(dots)-1]["selected"] == "true" {
+		return ErrLastPhoto
+	}
+	return d.ClickBySelectors(ctx, a.S.NextImage)
+}
+
+// AlbumLength counts the album dots of the top card. Single-photo cards have
+// none and fall back to the matching collected rec; 0 means unknown.
+func (a *Adapter) AlbumLength(ctx context.Context, d engine.IDriver) (int, error) {
+	dots, err := d.Records(ctx, a.S.AlbumDots, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(dots) > 0 {
+		return len(dots), nil
+	}
+	if rec, ok := a.currentRec(ctx, d); ok {
+		return len(rec.PhotoURLs), nil
+	}
+	return 0, nil
+}
+
+func (a *Adapter) Act(ctx context.Context, d engine.IDriver, action domain.AppAction) error {
+	switch action.Kind {
+	case domain.AppActionPass:
+		return d.ClickBySelectors(ctx, a.S.Pass)
+	case domain.AppActionLike:
+		return d.ClickBySelectors(ctx, a.S.Like)
+	case domain.AppActionSuperSwipe:
+		return d.ClickBySelectors(ctx, a.S.SuperLike)
+	case domain.AppActionUndo:
+		// Rewind is not wired up; fail rather than report an undo that
+		// never happened.
+		return errors.New("tinder: undo is not supported")
+	default:
+		return nil
+	}
+}
+
+func (a *Adapter) ScreenshotMedia(ctx context.Context, d engine.IDriver, filePath string) error {
+	return d.ScreenshotElement(ctx, a.S.AlbumView, filePath)
+}
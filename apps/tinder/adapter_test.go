@@ -0,0 +1,139 @@
+package tinder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/apps/internal/apptest"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func newTestDriver(t *testing.T, fb *fakebrowser.Browser) engine.IDriver {
+	return apptest.NewDriver(t, fb, "https://tinder.test/app/recs")
+}
+
+// showDots renders n album dots with the given one (1-based) selected.
+func showDots(fb *fakebrowser.Browser, a *Adapter, n, selected int) {
+	dots := make([]fakebrowser.Node, n)
+	for i := range dots {
+		sel := "false"
+		if i+1 == selected {
+			sel = "true"
+		}
+		dots[i] = fakebrowser.Node{Visible: true, Attrs: map[string]string{a.S.AlbumDotSelected: sel}}
+	}
+	fb.SetAll(a.S.AlbumDots, dots...)
+}
+
+func TestAdapterNextMediaClicksNext(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	showDots(fb, a, 3, 2)
+	fb.Show(a.S.NextImage[0])
+	d := newTestDriver(t, fb)
+
+	if err := a.NextMedia(context.Background(), d); err != nil {
+		t.Fatalf("NextMedia returned error: %v", err)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.NextImage[0]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestAdapterNextMediaStopsOnLastPhoto(t *testing.T) {
+	t.Parallel()
+
+	for name, dots := range map[string]int{"last selected": 3, "single photo": 0} {
+		a := NewAdapterFromDefaults()
+		fb := fakebrowser.New()
+		showDots(fb, a, dots, dots)
+		fb.Show(a.S.NextImage[0])
+		d := newTestDriver(t, fb)
+
+		if err := a.NextMedia(context.Background(), d); !errors.Is(err, ErrLastPhoto) {
+			t.Fatalf("%s: expected ErrLastPhoto, got %v", name, err)
+		}
+		if got := fb.Clicks(); len(got) != 0 {
+			t.Fatalf("%s: expected no clicks, got %v", name, got)
+		}
+	}
+}
+
+func TestAdapterActClicksMatchingControl(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	cases := []struct {
+		kind domain.AppActionType
+		want string
+	}{
+		{domain.AppActionPass, a.S.Pass[0]},
+		{domain.AppActionLike, a.S.Like[0]},
+		{domain.AppActionSuperSwipe, a.S.SuperLike[0]},
+	}
+
+	for _, tc := range cases {
+		fb := fakebrowser.New()
+		fb.Show(a.S.Pass[0], a.S.Like[0], a.S.SuperLike[0])
+		d := newTestDriver(t, fb)
+
+		if err := a.Act(context.Background(), d, domain.AppAction{Kind: tc.kind}); err != nil {
+			t.Fatalf("Act(%s) returned error: %v", tc.kind, err)
+		}
+		if got := fb.Clicks(); !reflect.DeepEqual(got, []string{tc.want}) {
+			t.Fatalf("Act(%s) clicked %v, want %s", tc.kind, got, tc.want)
+		}
+	}
+}
+
+func TestAdapterActFallsBackToSecondarySelector(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Show(a.S.Pass[1])
+	d := newTestDriver(t, fb)
+
+	if err := a.Act(context.Background(), d, domain.AppAction{Kind: domain.AppActionPass}); err != nil {
+		t.Fatalf("Act returned error: %v", err)
+	}
+	if got := fb.Clicks(); !reflect.DeepEqual(got, []string{a.S.Pass[1]}) {
+		t.Fatalf("unexpected clicks: %v", got)
+	}
+}
+
+func TestAdapterWaitReady(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	fb.Show(a.S.ReadyHints[1])
+	d := newTestDriver(t, fb)
+
+	if err := a.WaitReady(context.Background(), d); err != nil {
+		t.Fatalf("WaitReady returned error: %v", err)
+	}
+}
+
+func TestAdapterAlbumLength(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	showDots(fb, a, 4, 1)
+	d := newTestDriver(t, fb)
+
+	n, err := a.AlbumLength(context.Background(), d)
+	if err != nil {
+		t.Fatalf("AlbumLength returned error: %v", err)
+	}
+	if n != 4 {
+		t.Fatalf("AlbumLength = %d, want 4", n)
+	}
+}
@@ -0,0 +1,49 @@
+package tinder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/internal/cardkit"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// ReadProfileCard reads the name, age, facts and badges of the top card.
+// Facts the card hides (Tinder often shows only the name) are filled from the
+// matching collected rec. Only a missing name is an error.
+func (a *Adapter) ReadProfileCard(ctx context.Context, d engine.IDriver) (*domain.ProfileCard, error) {
+	card, err := cardkit.Read(ctx, d, cardkit.Fields{
+		Name:           a.S.CardName,
+		Age:            a.S.CardAge,
+		Distance:       a.S.CardDistance,
+		Location:       a.S.CardLocation,
+		Job:            a.S.CardJob,
+		Education:      a.S.CardEducation,
+		Verified:       a.S.CardVerified,
+		RecentlyActive: a.S.CardActive,
+	}, ErrProfileUnidentified)
+	if err != nil {
+		return nil, err
+	}
+	if rec, ok := a.currentRec(ctx, d); ok {
+		fillFromRec(card, rec)
+	}
+	return card, nil
+}
+
+func fillFromRec(card *domain.ProfileCard, rec Rec) {
+	if card.Job == "" && len(rec.Jobs) > 0 {
+		card.Job = rec.Jobs[0]
+	}
+	if card.Education == "" && len(rec.Schools) > 0 {
+		card.Education = strings.Join(rec.Schools, ", ")
+	}
+	if card.Location == "" {
+		card.Location = rec.City
+	}
+	if card.Distance == "" && rec.DistanceMi > 0 {
+		card.Distance = fmt.Sprintf("%d miles away", rec.DistanceMi)
+	}
+}
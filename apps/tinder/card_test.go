@@ -0,0 +1,68 @@
+package tinder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func TestAdapterReadProfileCard(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	showCard(fb, a, "Sam", "27", "https://images-ssl.gotinder.com/u/zz/1.jpeg")
+	fb.Set(a.S.CardDistance, fakebrowser.Node{Visible: true, Text: "2 miles away"})
+	fb.Set(a.S.CardJob, fakebrowser.Node{Visible: true, Text: "Nurse"})
+	fb.Set(a.S.CardVerified, fakebrowser.Node{Visible: true})
+	d := newTestDriver(t, fb)
+
+	got, err := a.ReadProfileCard(context.Background(), d)
+	if err != nil {
+		t.Fatalf("ReadProfileCard returned error: %v", err)
+	}
+	want := &domain.ProfileCard{Name: "Sam", Age: 27, Distance: "2 miles away", Job: "Nurse", Verified: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadProfileCard = %#v, want %#v", got, want)
+	}
+}
+
+func TestAdapterReadProfileCardFillsFromRec(t *testing.T) {
+	t.Parallel()
+
+	a := newCollectedAdapter(t)
+	fb := fakebrowser.New()
+	showCard(fb, a, "Ana", "29", "https://images-ssl.gotinder.com/u/aBc123/a1.jpeg?Policy=p1")
+	d := newTestDriver(t, fb)
+
+	got, err := a.ReadProfileCard(context.Background(), d)
+	if err != nil {
+		t.Fatalf("ReadProfileCard returned error: %v", err)
+	}
+	want := &domain.ProfileCard{
+		Name:      "Ana",
+		Age:       29,
+		Distance:  "4 miles away",
+		Location:  "Leeds",
+		Job:       "Product Designer at Studio North",
+		Education: "University of Leeds",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadProfileCard = %#v, want %#v", got, want)
+	}
+}
+
+func TestAdapterReadProfileCardNeedsName(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	d := newTestDriver(t, fakebrowser.New())
+
+	if _, err := a.ReadProfileCard(context.Background(), d); !errors.Is(err, ErrProfileUnidentified) {
+		t.Fatalf("expected ErrProfileUnidentified, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package tinder
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/internal/cardkit"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// ProfileKeyPrefix starts every Tinder profile key.
+const ProfileKeyPrefix = "tinder_"
+
+// ErrProfileUnidentified means the top card has no readable name to hash.
+var ErrProfileUnidentified = fmt.Errorf("tinder: %w", domain.ErrProfileUnidentified)
+
+// GetProfileId derives a stable key for the top card, in order of preference:
+//
+//  1. the user id of the collected rec serving the photo on screen,
+//  2. the user id of the only collected rec with the same name and age,
+//  3. a hash of name, age and the photo URL (without query string).
+//
+// Call it before paging the album: the hash uses the photo currently shown.
+func (a *Adapter) GetProfileId(ctx context.Context, d engine.IDriver) (string, error) {
+	c, err := a.readIdentity(ctx, d)
+	if err != nil {
+		return "", err
+	}
+	if c.name == "" {
+		return "", ErrProfileUnidentified
+	}
+	if rec, ok := a.matchRec(c); ok {
+		return profileKey(rec.UserID), nil
+	}
+	return cardkit.HashKey(ProfileKeyPrefix, c.name, c.age, c.photo), nil
+}
+
+// cardIdentity is what the top card shows about who it is.
+type cardIdentity struct {
+	name, age, photo string
+}
+
+func (a *Adapter) readIdentity(ctx context.Context, d engine.IDriver) (cardIdentity, error) {
+	var c cardIdentity
+	var err error
+	if c.name, err = cardkit.FirstText(ctx, d, a.S.CardName); err != nil {
+		return c, err
+	}
+	ageText, err := cardkit.FirstText(ctx, d, a.S.CardAge)
+	if err != nil {
+		return c, err
+	}
+	c.age = cardkit.Digits(ageText)
+	style, _, err := d.Attribute(ctx, a.S.CardPhoto, "style")
+	if err != nil {
+		return c, fmt.Errorf("read photo style: %w", err)
+	}
+	c.photo = cardkit.StripQuery(backgroundURL(style))
+	return c, nil
+}
+
+// currentRec returns the collected rec for the top card, if any.
+func (a *Adapter) currentRec(ctx context.Context, d engine.IDriver) (Rec, bool) {
+	c, err := a.readIdentity(ctx, d)
+	if err != nil || c.name == "" {
+		return Rec{}, false
+	}
+	return a.matchRec(c)
+}
+
+func (a *Adapter) matchRec(c cardIdentity) (Rec, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if c.photo != "" {
+		for _, id := range a.recOrder {
+			for _, u := range a.recs[id].PhotoURLs {
+				if cardkit.StripQuery(u) == c.photo {
+					return a.recs[id], true
+				}
+			}
+		}
+	}
+
+	var match Rec
+	found := false
+	now := a.now()
+	for _, id := range a.recOrder {
+		rec := a.recs[id]
+		if rec.Name != c.name || strconv.Itoa(rec.Age(now)) != c.age {
+			continue
+		}
+		if found {
+			return Rec{}, false
+		}
+		match, found = rec, true
+	}
+	return match, found
+}
+
+var bgURL = regexp.MustCompile(`url\(\s*["']?([^"')]+)`)
+
+func profileKey(id string) string { return cardkit.Key(ProfileKeyPrefix, id) }
+
+// backgroundURL pulls the image URL out of an inline background-image style.
+func backgroundURL(style string) string {
+	m := bgURL.FindStringSubmatch(strings.ReplaceAll(style, "&quot;", `"`))
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
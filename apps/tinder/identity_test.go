@@ -0,0 +1,105 @@
+package tinder
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func showCard(fb *fakebrowser.Browser, a *Adapter, name, age, photo string) {
+	fb.Set(a.S.CardName, fakebrowser.Node{Visible: true, Text: name})
+	fb.Set(a.S.CardAge, fakebrowser.Node{Visible: true, Text: age})
+	fb.Set(a.S.CardPhoto, fakebrowser.Node{Visible: true, Attrs: map[string]string{
+		"style": `background-image: url("` + photo + `"); background-position: 50% 50%;`,
+	}})
+}
+
+func TestGetProfileIdMatchesRecPhoto(t *testing.T) {
+	t.Parallel()
+
+	a := newCollectedAdapter(t)
+	fb := fakebrowser.New()
+	// the card shows a re-signed URL of Ana's second photo under another name
+	showCard(fb, a, "A.", "", "https://images-ssl.gotinder.com/u/aBc123/a2.jpeg?Policy=other&Signature=other")
+	d := newTestDriver(t, fb)
+
+	got, err := a.GetProfileId(context.Background(), d)
+	if err != nil {
+		t.Fatalf("GetProfileId returned error: %v", err)
+	}
+	if got != "tinder_64f1c0ffee5a1d0001a7b2c3" {
+		t.Fatalf("GetProfileId = %q", got)
+	}
+}
+
+func TestGetProfileIdMatchesRecNameAndAge(t *testing.T) {
+	t.Parallel()
+
+	a := newCollectedAdapter(t)
+	fb := fakebrowser.New()
+	showCard(fb, a, "Leah", "31", "https://images-ssl.gotinder.com/u/unknown/x.webp")
+	d := newTestDriver(t, fb)
+
+	got, err := a.GetProfileId(context.Background(), d)
+	if err != nil {
+		t.Fatalf("GetProfileId returned error: %v", err)
+	}
+	if got != "tinder_64f1c0ffee5a1d0001a7b2d4" {
+		t.Fatalf("GetProfileId = %q", got)
+	}
+}
+
+func TestGetProfileIdHashIsStable(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	key := func(photo string) string {
+		fb := fakebrowser.New()
+		showCard(fb, a, "Sam", "27", photo)
+		got, err := a.GetProfileId(context.Background(), newTestDriver(t, fb))
+		if err != nil {
+			t.Fatalf("GetProfileId returned error: %v", err)
+		}
+		return got
+	}
+
+	first := key("https://images-ssl.gotinder.com/u/zz/1.jpeg?Signature=a")
+	if !strings.HasPrefix(first, ProfileKeyPrefix+"h") {
+		t.Fatalf("expected hashed key, got %q", first)
+	}
+	if again := key("https://images-ssl.gotinder.com/u/zz/1.jpeg?Signature=b"); again != first {
+		t.Fatalf("key changed with signed query: %q vs %q", again, first)
+	}
+	if other := key("https://images-ssl.gotinder.com/u/zz/2.jpeg"); other == first {
+		t.Fatalf("different photo gave the same key %q", other)
+	}
+}
+
+func TestGetProfileIdNeedsName(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	d := newTestDriver(t, fakebrowser.New())
+
+	if _, err := a.GetProfileId(context.Background(), d); !errors.Is(err, ErrProfileUnidentified) {
+		t.Fatalf("expected ErrProfileUnidentified, got %v", err)
+	}
+}
+
+func TestBackgroundURL(t *testing.T) {
+	t.Parallel()
+
+	for style, want := range map[string]string{
+		`background-image: url("https://x.test/a.jpg?s=1");`:      "https://x.test/a.jpg?s=1",
+		`background-image: url(&quot;https://x.test/b.jpg&quot;)`: "https://x.test/b.jpg",
+		`background-image:url(https://x.test/c.jpg)`:              "https://x.test/c.jpg",
+		`background-size: cover;`:                                 "",
+	} {
+		if got := backgroundURL(style); got != want {
+			t.Fatalf("backgroundURL(%q) = %q, want %q", style, got, want)
+		}
+	}
+}
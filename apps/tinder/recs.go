@@ -0,0 +1,146 @@
+package tinder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+)
+
+// Rec is one profile from the recs API, as served to the web app.
+type Rec struct {
+	UserID    string
+	Name      string
+	BirthDate time.Time
+	Bio       string
+	PhotoURLs []string
+	Jobs      []string
+	Schools   []string
+	City      string
+	// DistanceMi is the distance Tinder reports, in miles.
+	DistanceMi int
+}
+
+// Age is the rec's age on now, from its birth date; 0 when unknown.
+func (r Rec) Age(now time.Time) int {
+	if r.BirthDate.IsZero() {
+		return 0
+	}
+	age := now.Year() - r.BirthDate.Year()
+	if now.Month() < r.BirthDate.Month() || now.Month() == r.BirthDate.Month() && now.Day() < r.BirthDate.Day() {
+		age--
+	}
+	return age
+}
+
+// recsPayload is the subset of the /v2/recs/core response we read.
+type recsPayload struct {
+	Data struct {
+		Results []struct {
+			Type       string  `json:"type"`
+			User       recUser `json:"user"`
+			DistanceMi int     `json:"distance_mi"`
+		} `json:"results"`
+	} `json:"data"`
+}
+
+type recUser struct {
+	ID        string    `json:"_id"`
+	Name      string    `json:"name"`
+	Bio       string    `json:"bio"`
+	BirthDate time.Time `json:"birth_date"`
+	Photos    []struct {
+		URL string `json:"url"`
+	} `json:"photos"`
+	Jobs []struct {
+		Title   *named `json:"title"`
+		Company *named `json:"company"`
+	} `json:"jobs"`
+	Schools []named `json:"schools"`
+	City    *named  `json:"city"`
+}
+
+type named struct {
+	Name string `json:"name"`
+}
+
+// CollectResponses watches the recs API so every profile the web app loads
+// is kept (see Rec). Call it before the page is opened.
+func (a *Adapter) CollectResponses(ctx context.Context, d engine.IDriver) (func(), error) {
+	return d.WatchResponses(ctx, a.S.RecsAPI, func(r engine.NetworkResponse) {
+		if err := a.addRecs(r); err != nil {
+			log.Printf("tinder: %v", err)
+		}
+	})
+}
+
+func (a *Adapter) addRecs(r engine.NetworkResponse) error {
+	var p recsPayload
+	if err := r.DecodeJSON(&p); err != nil {
+		return fmt.Errorf("decode recs payload from %s: %w", r.URL, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, res := range p.Data.Results {
+		if res.Type != "user" || res.User.ID == "" {
+			continue
+		}
+		rec := res.User.rec()
+		rec.DistanceMi = res.DistanceMi
+		if _, seen := a.recs[rec.UserID]; !seen {
+			a.recOrder = append(a.recOrder, rec.UserID)
+		}
+		a.recs[rec.UserID] = rec
+	}
+	return nil
+}
+
+func (u recUser) rec() Rec {
+	rec := Rec{UserID: u.ID, Name: u.Name, Bio: u.Bio, BirthDate: u.BirthDate}
+	for _, ph := range u.Photos {
+		if ph.URL != "" {
+			rec.PhotoURLs = append(rec.PhotoURLs, ph.URL)
+		}
+	}
+	for _, j := range u.Jobs {
+		switch {
+		case j.Title != nil && j.Company != nil:
+			rec.Jobs = append(rec.Jobs, j.Title.Name+" at "+j.Company.Name)
+		case j.Title != nil:
+			rec.Jobs = append(rec.Jobs, j.Title.Name)
+		case j.Company != nil:
+			rec.Jobs = append(rec.Jobs, j.Company.Name)
+		}
+	}
+	for _, s := range u.Schools {
+		if s.Name != "" {
+			rec.Schools = append(rec.Schools, s.Name)
+		}
+	}
+	if u.City != nil {
+		rec.City = u.City.Name
+	}
+	return rec
+}
+
+// Recs returns the collected profiles in the order they first arrived.
+func (a *Adapter) Recs() []Rec {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]Rec, 0, len(a.recOrder))
+	for _, id := range a.recOrder {
+		out = append(out, a.recs[id])
+	}
+	return out
+}
+
+// Rec looks up a collected profile by user id.
+func (a *Adapter) Rec(userID string) (Rec, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	rec, ok := a.recs[userID]
+	return rec, ok
+}
@@ -0,0 +1,102 @@
+package tinder
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+const recsURL = "https://api.gotinder.com/v2/recs/core?locale=en-GB"
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	buf, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	return buf
+}
+
+// newCollectedAdapter returns an adapter that has seen recs_core.json, with
+// its clock fixed so rec ages are stable.
+func newCollectedAdapter(t *testing.T) *Adapter {
+	t.Helper()
+	a := NewAdapterFromDefaults()
+	a.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	if err := a.addRecs(engine.NetworkResponse{URL: recsURL, Body: readFixture(t, "recs_core.json")}); err != nil {
+		t.Fatalf("addRecs returned error: %v", err)
+	}
+	return a
+}
+
+func TestAdapterCollectsRecsPayload(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	fb := fakebrowser.New()
+	d := newTestDriver(t, fb)
+
+	stop, err := a.CollectResponses(context.Background(), d)
+	if err != nil {
+		t.Fatalf("CollectResponses returned error: %v", err)
+	}
+	defer stop()
+
+	fb.RespondJSON(recsURL, readFixture(t, "recs_core.json"))
+	fb.RespondJSON("https://api.gotinder.com/v2/profile?include=likes", []byte(`{"data":{}}`))
+
+	got := a.Recs()
+	if len(got) != 2 {
+		t.Fatalf("collected %d recs, want 2 (ads skipped): %#v", len(got), got)
+	}
+	want := Rec{
+		UserID:    "64f1c0ffee5a1d0001a7b2c3",
+		Name:      "Ana",
+		BirthDate: time.Date(1996, 3, 2, 8, 14, 52, 671000000, time.UTC),
+		Bio:       "Climbing on weekends, ramen on weekdays.",
+		PhotoURLs: []string{
+			"https://images-ssl.gotinder.com/u/aBc123/a1.jpeg?Policy=p1&Signature=s1&Key-Pair-Id=k",
+			"https://images-ssl.gotinder.com/u/aBc123/a2.jpeg?Policy=p2&Signature=s2&Key-Pair-Id=k",
+			"https://images-ssl.gotinder.com/u/aBc123/a3.jpeg?Policy=p3&Signature=s3&Key-Pair-Id=k",
+		},
+		Jobs:       []string{"Product Designer at Studio North"},
+		Schools:    []string{"University of Leeds"},
+		City:       "Leeds",
+		DistanceMi: 4,
+	}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("first rec = %#v, want %#v", got[0], want)
+	}
+	if rec, ok := a.Rec("64f1c0ffee5a1d0001a7b2d4"); !ok || rec.Name != "Leah" || rec.DistanceMi != 11 {
+		t.Fatalf("Rec lookup = %#v, %v", rec, ok)
+	}
+}
+
+func TestAdapterRecsRejectsBadPayload(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	if err := a.addRecs(engine.NetworkResponse{URL: recsURL, Body: []byte("<html>")}); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
+
+func TestRecAge(t *testing.T) {
+	t.Parallel()
+
+	r := Rec{BirthDate: time.Date(1996, 3, 2, 0, 0, 0, 0, time.UTC)}
+	if got := r.Age(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)); got != 28 {
+		t.Fatalf("Age before birthday = %d, want 28", got)
+	}
+	if got := r.Age(time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)); got != 29 {
+		t.Fatalf("Age on birthday = %d, want 29", got)
+	}
+	if got := (Rec{}).Age(time.Now()); got != 0 {
+		t.Fatalf("Age without birth date = %d, want 0", got)
+	}
+}
@@ -0,0 +1,115 @@
+package tinder
+
+import (
+	"fmt"
+
+	"github.com/vd09-projects/swipeassist/apps/declarative"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+type Selectors struct {
+	NextImage  []string
+	Pass       []string
+	SuperLike  []string
+	Like       []string
+	ReadyHints []string
+	AlbumView  string // photo carousel of the top card, screenshotted per photo
+
+	// AlbumDots has one tab per photo; the shown one has aria-selected="true".
+	AlbumDots        string
+	AlbumDotSelected string // attribute on AlbumDots
+
+	// Current card identity.
+	CardName  string
+	CardAge   string
+	CardPhoto string // shown slide; the URL is in its style background-image
+
+	// Card facts and badges.
+	CardDistance  string
+	CardLocation  string
+	CardJob       string
+	CardEducation string
+	CardVerified  string
+	CardActive    string
+
+	// RecsAPI are URL globs of the XHRs that deliver the recommendation deck.
+	RecsAPI []string
+}
+
+func DefaultSelectors() Selectors {
+	return Selectors{
+		NextImage: []string{
+			"div.recsCardboard__cards button[aria-label='Next Photo']",
+			"div.recsCardboard__cards div.tappable_recCard span.tappable-view__next",
+		},
+		Pass: []string{
+			"div.recsCardboard__cardsContainer button[aria-label='Nope']",
+			"div.gamepad-button-wrapper button.gamepad-button--nope",
+		},
+		SuperLike: []string{
+			"div.recsCardboard__cardsContainer button[aria-label='Super Like']",
+			"div.gamepad-button-wrapper button.gamepad-button--super-like",
+		},
+		Like: []string{
+			"div.recsCardboard__cardsContainer button[aria-label='Like']",
+			"div.gamepad-button-wrapper button.gamepad-button--like",
+		},
+		ReadyHints: []string{
+			"div.recsCardboard__cards",
+			"main[role='main']",
+		},
+		AlbumView: "div.recsCardboard__cards div.keen-slider",
+
+		AlbumDots:        "div.recsCardboard__cards div[role='tablist'] button[role='tab']",
+		AlbumDotSelected: "aria-selected",
+
+		CardName:  "div.recsCardboard__cards span[itemprop='name']",
+		CardAge:   "div.recsCardboard__cards span[itemprop='age']",
+		CardPhoto: "div.recsCardboard__cards div.keen-slider__slide[aria-hidden='false'] div[role='img']",
+
+		CardDistance:  "div.recsCardboard__cards div[itemprop='distance']",
+		CardLocation:  "div.recsCardboard__cards div[itemprop='homeLocation']",
+		CardJob:       "div.recsCardboard__cards div[itemprop='jobTitle']",
+		CardEducation: "div.recsCardboard__cards div[itemprop='affiliation']",
+		CardVerified:  "div.recsCardboard__cards svg[aria-label='Verified!']",
+		CardActive:    "div.recsCardboard__cards span.recently-active",
+
+		RecsAPI: []string{
+			"https://api.gotinder.com/v2/recs/core*",
+		},
+	}
+}
+
+// ApplySpec takes the selectors a declarative spec for TINDER sets, so a
+// selector fix is a YAML change; the rest keep their defaults. Settings
+// Tinder can't honour are rejected: the next-photo control is never
+// disabled (NextMedia counts AlbumDots instead), and cards carry no id. The
+// photo attribute is ignored; the URL is read from the slide's style.
+func (a *Adapter) ApplySpec(s declarative.Spec) error {
+	switch {
+	case len(s.NextMedia.Disabled) > 0:
+		return fmt.Errorf("%s: next_media.disabled is not supported for %s", s.Path, s.App)
+	case s.Card.Root != "" || len(s.Card.IDAttrs) > 0:
+		return fmt.Errorf("%s: card.root and card.id_attrs are not supported for %s", s.Path, s.App)
+	}
+
+	declarative.Overlay(&a.EntryURL, s.EntryURL)
+	declarative.Overlay(&a.S.ReadyHints, s.ReadyHints)
+	declarative.Overlay(&a.S.NextImage, s.NextMedia.Click)
+	declarative.Overlay(&a.S.Pass, s.Actions[domain.AppActionPass])
+	declarative.Overlay(&a.S.Like, s.Actions[domain.AppActionLike])
+	declarative.Overlay(&a.S.SuperLike, s.Actions[domain.AppActionSuperSwipe])
+	declarative.Overlay(&a.S.AlbumView, s.MediaCapture)
+
+	c := s.Card
+	declarative.Overlay(&a.S.CardName, c.Name)
+	declarative.Overlay(&a.S.CardAge, c.Age)
+	declarative.Overlay(&a.S.CardPhoto, c.Photo)
+	declarative.Overlay(&a.S.CardDistance, c.Distance)
+	declarative.Overlay(&a.S.CardLocation, c.Location)
+	declarative.Overlay(&a.S.CardJob, c.Job)
+	declarative.Overlay(&a.S.CardEducation, c.Education)
+	declarative.Overlay(&a.S.CardVerified, c.Verified)
+	declarative.Overlay(&a.S.CardActive, c.RecentlyActive)
+	return nil
+}
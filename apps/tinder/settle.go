@@ -0,0 +1,24 @@
+package tinder
+
+import (
+	"context"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+)
+
+// WaitSettled returns once the deck has loaded and the top card's album has
+// stopped sliding. Tinder paints photos as CSS backgrounds, so there are no
+// <img> elements to wait on.
+func (a *Adapter) WaitSettled(ctx context.Context, d engine.IDriver) error {
+	return d.WaitFor(ctx,
+		engine.NetworkIdle(a.SettleQuiet),
+		engine.BoxStable(a.S.AlbumView, a.SettleQuiet/2),
+		engine.DOMStable(a.S.AlbumView, a.SettleQuiet),
+	)
+}
+
+// WaitMediaSettled returns once the album has finished sliding to the photo
+// NextMedia picked.
+func (a *Adapter) WaitMediaSettled(ctx context.Context, d engine.IDriver) error {
+	return d.WaitFor(ctx, engine.DOMStable(a.S.AlbumView, a.SettleQuiet/2))
+}
@@ -0,0 +1,32 @@
+package tinder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine/fakebrowser"
+)
+
+func TestWaitMediaSettledWaitsForSlide(t *testing.T) {
+	t.Parallel()
+
+	a := NewAdapterFromDefaults()
+	a.SettleQuiet = 100 * time.Millisecond
+	fb := fakebrowser.New()
+	fb.Show(a.S.AlbumView)
+	d := newTestDriver(t, fb)
+	time.Sleep(60 * time.Millisecond)
+	fb.Set(a.S.AlbumView, fakebrowser.Node{Visible: true}) // the slider re-renders on the next photo
+
+	start := time.Now()
+	if err := a.WaitMediaSettled(context.Background(), d); err != nil {
+		t.Fatalf("WaitMediaSettled returned error: %v", err)
+	}
+	if took := time.Since(start); took < 40*time.Millisecond {
+		t.Fatalf("WaitMediaSettled returned after %s, while the album was still changing", took)
+	}
+	if err := a.WaitSettled(context.Background(), d); err != nil {
+		t.Fatalf("WaitSettled returned error: %v", err)
+	}
+}
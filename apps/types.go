@@ -13,9 +13,100 @@ type Adapter interface {
 
 	WaitReady(ctx context.Context, d engine.IDriver) error
 
-	GetProfileId(ctx context.Context) string
+	// GetProfileId returns a key for the card on screen that is stable
+	// across runs.
+	GetProfileId(ctx context.Context, d engine.IDriver) (string, error)
 	NextMedia(ctx context.Context, d engine.IDriver) error
 	Act(ctx context.Context, d engine.IDriver, action domain.AppAction) error
 
 	ScreenshotMedia(ctx context.Context, d engine.IDriver, filePath string) error
 }
+
+// CardReader is implemented by adapters that can read the facts shown on the
+// current card (name, age, location, badges). A card without a readable name
+// fails with an error wrapping domain.ErrProfileUnidentified.
+type CardReader interface {
+	ReadProfileCard(ctx context.Context, d engine.IDriver) (*domain.ProfileCard, error)
+}
+
+// BehaviourReader is implemented by adapters that can read profile text
+// (Q&A, tags, bio) from the DOM instead of screenshots.
+type BehaviourReader interface {
+	ReadBehaviour(ctx context.Context, d engine.IDriver) (*domain.BehaviourTraits, error)
+}
+
+// ResponseCollector is implemented by adapters that read structured data from
+// the app's own XHR/fetch responses. GenericClient.Open subscribes it before
+// navigating; stop is called on Close.
+type ResponseCollector interface {
+	CollectResponses(ctx context.Context, d engine.IDriver) (stop func(), err error)
+}
+
+// ProfileCapturer is implemented by adapters that can capture the whole
+// profile card as one stitched image (plus optional per-section crops).
+type ProfileCapturer interface {
+	ScreenshotProfile(ctx context.Context, d engine.IDriver, filePath string, keepSections bool) (sections []string, err error)
+}
+
+// AlbumCounter is implemented by adapters that can tell how many photos the
+// current card's album holds. Zero means the length is unknown.
+type AlbumCounter interface {
+	AlbumLength(ctx context.Context, d engine.IDriver) (int, error)
+}
+
+// Settler is implemented by adapters that can tell when the screen has
+// finished loading and animating (see engine.IDriver.WaitFor), so callers
+// need no fixed sleeps. WaitSettled covers a new card; WaitMediaSettled only
+// the photo shown after NextMedia.
+type Settler interface {
+	WaitSettled(ctx context.Context, d engine.IDriver) error
+	WaitMediaSettled(ctx context.Context, d engine.IDriver) error
+}
+
+// MessageActor is implemented by adapters that can send an action's Message
+// with a like or superswipe (a compliment or opener). When the app doesn't
+// offer a composer it applies the plain action and reports sent=false; when
+// the message fails after the action was applied, the error wraps
+// domain.ErrMessageNotSent.
+type MessageActor interface {
+	ActWithMessage(ctx context.Context, d engine.IDriver, action domain.AppAction) (sent bool, err error)
+}
+
+// StateReader is implemented by adapters that model the app's screens.
+// CurrentState classifies what is on screen right now, without waiting;
+// DismissModal closes the dialog behind UIStateModal.
+type StateReader interface {
+	CurrentState(ctx context.Context, d engine.IDriver) (domain.UIState, error)
+	DismissModal(ctx context.Context, d engine.IDriver) error
+}
+
+// Loginer is implemented by adapters that can sign in on their own. Login
+// enters creds, asks otp for the code the app sends and returns once the app
+// is past the login screen; LoggedIn checks that without waiting.
+type Loginer interface {
+	LoggedIn(ctx context.Context, d engine.IDriver) (bool, error)
+	Login(ctx context.Context, d engine.IDriver, creds domain.Credentials, otp domain.OTPFunc) error
+}
+
+// BlockDetector is implemented by adapters that can recognise the app's
+// blocking states (out of likes, paywall, empty deck). DetectBlock returns
+// nil when nothing is blocking.
+type BlockDetector interface {
+	DetectBlock(ctx context.Context, d engine.IDriver) (*domain.BlockedError, error)
+}
+
+// MatchHandler is implemented by adapters that can spot the app's match
+// overlay after a like and handle it per mode. It returns nil when no overlay
+// shows up.
+type MatchHandler interface {
+	HandleMatch(ctx context.Context, d engine.IDriver, mode domain.MatchMode) (*domain.Match, error)
+}
+
+// Messenger is implemented by adapters that can drive the app's chat: list
+// conversations, open one by id, read the open one and send into it.
+type Messenger interface {
+	ListConversations(ctx context.Context, d engine.IDriver) ([]domain.Conversation, error)
+	OpenConversation(ctx context.Context, d engine.IDriver, id string) error
+	ReadConversation(ctx context.Context, d engine.IDriver) (*domain.Conversation, error)
+	SendMessage(ctx context.Context, d engine.IDriver, text string) error
+}
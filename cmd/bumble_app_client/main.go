@@ -17,9 +17,10 @@ func main() {
 		loginURL  = flag.String("login-url", "https://bumble.com/app", "Bumble URL (ensure you are logged in)")
 		outDir    = flag.String("out", "./out/bumble", "Directory for screenshots")
 		headless  = flag.Bool("headless", false, "Run headless")
-		control   = flag.String("remote-url", "", "Rod ControlURL (optional). If empty, launches a new browser")
+		control   = flag.String("remote-url", "", "DevTools address of a running browser: port, host:port or ws:// URL (optional). If empty, launches a new browser")
 		totalN    = flag.Int("totalImages", 20, "How many times Screenshot (client-controlled)")
 		actionStr = flag.String("action", "LIKE", "PASS | LIKE | SUPERSWIPE")
+		message   = flag.String("message", "", "Compliment sent with LIKE or SUPERSWIPE when the app offers a composer")
 	)
 	flag.Parse()
 
@@ -69,7 +70,7 @@ func main() {
 	// 4) Sleep before clicking action (as requested)
 	time.Sleep(20 * time.Second)
 
-	var act domain.AppAction
+	act := domain.AppAction{Message: *message}
 	switch *actionStr {
 	case "PASS":
 		act.Kind = domain.AppActionPass
@@ -81,9 +82,16 @@ func main() {
 		panic("invalid -action. Use PASS | LIKE | SUPERSWIPE")
 	}
 
-	if err := client.Act(ctx, act); err != nil {
+	res, err := client.Act(ctx, act)
+	if err != nil {
 		panic(err)
 	}
+	if act.Message != "" && !res.MessageSent {
+		fmt.Println("No compliment composer offered; applied the plain action.")
+	}
+	if res.Match != nil {
+		fmt.Printf("It's a match with %s!\n", res.Match.Name)
+	}
 
 	fmt.Println("Done.")
 }
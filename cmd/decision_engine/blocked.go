@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/analytics"
+	"github.com/vd09-projects/swipeassist/apps"
+	"github.com/vd09-projects/swipeassist/decisionengine/policies"
+	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/swipeassist/utils"
+)
+
+// resumeMargin is added to the app's reset time so the reload doesn't land
+// a moment before the quota actually refills.
+const resumeMargin = 30 * time.Second
+
+// heldAction keeps a decision whose action a block swallowed (see
+// apps.ErrNotApplied), so when the same card is back after the pause it is
+// applied again without new LLM calls or a second decision row.
+type heldAction struct {
+	profileKey string
+	decision   *policies.Decision
+	decisionID int64
+}
+
+// take returns the held decision for profileKey and forgets it; a different
+// card drops it.
+func (h *heldAction) take(profileKey string) (*policies.Decision, int64, bool) {
+	if h.decision == nil {
+		return nil, 0, false
+	}
+	d, id, ok := h.decision, h.decisionID, h.profileKey == profileKey
+	*h = heldAction{}
+	return d, id, ok
+}
+
+// waitOutBlock handles a blocking state: it sleeps until the reset the app
+// showed and reloads, or reports resume=false to end the run cleanly when
+// there is no reset, it is beyond -max-pause, or past the run's deadline.
+func waitOutBlock(ctx context.Context, cfg *Config, client *apps.GenericClient, session *analytics.Session, blk *domain.BlockedError) (resume bool, err error) {
+	if session != nil {
+		session.Inc("blocked."+string(blk.Kind), 1)
+	}
+	if blk.ResetAt.IsZero() {
+		log.Printf("%v; ending run", blk)
+		return false, nil
+	}
+	wait := time.Until(blk.ResetAt) + resumeMargin
+	if wait > cfg.MaxPause {
+		log.Printf("%v; reset is %s away (over -max-pause %s); ending run", blk, wait.Round(time.Second), cfg.MaxPause)
+		return false, nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+		log.Printf("%v; reset is after the run's timeout; ending run", blk)
+		return false, nil
+	}
+
+	log.Printf("%v; pausing %s", blk, wait.Round(time.Second))
+	if err := utils.SleepCtx(ctx, wait); err != nil {
+		return false, err
+	}
+	// A reload that is still blocked is picked up by the next check.
+	if err := client.Open(ctx); err != nil && !errors.As(err, new(*domain.BlockedError)) {
+		return false, fmt.Errorf("reopen after pause: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/vd09-projects/swipeassist/analytics"
+	"github.com/vd09-projects/swipeassist/apps"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// supervisor keeps a long run going through browser crashes and closed
+// tabs: after a failed step it asks the client to reconnect and reopen the
+// app (see apps.GenericClient.Recover), at most -max-reconnects times.
+type supervisor struct {
+	client  *apps.GenericClient
+	session *analytics.Session
+	max     int
+	used    int
+}
+
+// recover reports retry=true when the browser was lost and is back, so the
+// step that failed with cause should run again. A cause the browser is not
+// to blame for gives retry=false and a nil error.
+func (s *supervisor) recover(ctx context.Context, cause error) (retry bool, err error) {
+	if ctx.Err() != nil || s.used >= s.max {
+		return false, nil
+	}
+	reconnected, err := s.client.Recover(ctx)
+	if !reconnected {
+		return false, nil
+	}
+	s.used++
+	if s.session != nil {
+		s.session.Inc("reconnects", 1)
+	}
+	// Screens shown after the reopen are handled by settleOnCard.
+	if err != nil && !errors.As(err, new(*domain.BlockedError)) && !errors.Is(err, apps.ErrErrorPage) {
+		return false, fmt.Errorf("reconnect after %v: %w", cause, err)
+	}
+	log.Printf("reconnected after %v (%d/%d)", cause, s.used, s.max)
+	return true, nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/analytics"
+	"github.com/vd09-projects/swipeassist/apps"
+	appengine "github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/swipeassist/utils"
+)
+
+const (
+	maxSettleSteps = 8               // screens handled before giving up on reaching a card
+	loadingPoll    = 1 * time.Second // recheck interval while the app is loading
+)
+
+// settleOnCard brings the app to a card before a profile is processed:
+// modals are dismissed, loading and error pages are waited out or reloaded,
+// blocking states pause or end the run (see waitOutBlock), and a login
+// screen is reopened once so the client can log back in (-vault). It returns
+// proceed=false when the run should end cleanly. Adapters that don't model
+// their screens only get the blocking-state check.
+func settleOnCard(ctx context.Context, cfg *Config, client *apps.GenericClient, session *analytics.Session) (proceed bool, err error) {
+	reloaded, relogged := false, false
+	for step := 0; step < maxSettleSteps; step++ {
+		state, err := client.CurrentState(ctx)
+		if errors.Is(err, apps.ErrUnsupported) {
+			state, err = domain.UIStateCard, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("read screen state: %w", err)
+		}
+
+		switch state {
+		case domain.UIStateCard, domain.UIStateUnknown, domain.UIStateBlocked, domain.UIStateDeckEmpty:
+			blk, err := client.Blocked(ctx)
+			if err != nil {
+				return false, fmt.Errorf("check blocking state: %w", err)
+			}
+			if blk == nil {
+				if state == domain.UIStateDeckEmpty {
+					log.Printf("deck is empty; ending run")
+					return false, nil
+				}
+				return true, nil
+			}
+			if resume, err := waitOutBlock(ctx, cfg, client, session, blk); !resume || err != nil {
+				return false, err
+			}
+		case domain.UIStateModal:
+			log.Printf("dismissing modal")
+			if session != nil {
+				session.Inc("modals_dismissed", 1)
+			}
+			if err := client.DismissModal(ctx); err != nil {
+				return false, err
+			}
+		case domain.UIStateLoading:
+			if err := utils.SleepCtx(ctx, loadingPoll); err != nil {
+				return false, err
+			}
+		case domain.UIStateError:
+			if reloaded {
+				return false, apps.ErrErrorPage
+			}
+			log.Printf("app shows an error page; reloading")
+			reloaded = true
+			if err := client.Open(ctx); err != nil && !errors.Is(err, apps.ErrErrorPage) {
+				var blk *domain.BlockedError
+				if !errors.As(err, &blk) {
+					return false, fmt.Errorf("reload: %w", err)
+				}
+			}
+		case domain.UIStateLoggedOut:
+			if cfg.VaultPath == "" || relogged {
+				return false, apps.ErrLoggedOut
+			}
+			log.Printf("session expired; logging back in")
+			relogged = true
+			if session != nil {
+				session.Inc("relogins", 1)
+			}
+			if err := client.Open(ctx); err != nil {
+				var blk *domain.BlockedError
+				if !errors.As(err, &blk) && !errors.Is(err, apps.ErrErrorPage) {
+					return false, fmt.Errorf("log back in: %w", err)
+				}
+			}
+		}
+	}
+	return false, fmt.Errorf("no card after %d screen(s)", maxSettleSteps)
+}
+
+// waitSettled runs one of the client's settle waits (see apps.Settler).
+// Adapters that can't tell get the fixed fallback sleep instead. A wait that
+// runs out is only logged; the run goes on with what is on screen.
+func waitSettled(ctx context.Context, wait func(context.Context) error, fallback time.Duration) error {
+	err := wait(ctx)
+	switch {
+	case errors.Is(err, apps.ErrUnsupported):
+		return utils.SleepCtx(ctx, fallback)
+	case errors.Is(err, appengine.ErrWaitTimeout):
+		log.Printf("screen did not settle: %v", err)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/analytics"
+	"github.com/vd09-projects/swipeassist/apps"
+	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/swipeassist/internal/persistence"
+)
+
+const defaultUndoReason = "flagged by reviewer"
+
+// reviewer turns lines typed by a human watching the run into undo flags.
+// A line starting with "u" (u, undo) flags the last action; anything after
+// the first word is the reason.
+type reviewer struct {
+	flags chan string
+}
+
+func newReviewer(r io.Reader) *reviewer {
+	rv := &reviewer{flags: make(chan string, 8)}
+	go func() {
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			reason, ok := parseUndoLine(sc.Text())
+			if !ok {
+				continue
+			}
+			select {
+			case rv.flags <- reason:
+			default: // nobody is waiting and the buffer is full
+			}
+		}
+	}()
+	return rv
+}
+
+// parseUndoLine accepts "u" or "undo" (any case), optionally followed by a
+// reason; any other line is ignored.
+func parseUndoLine(line string) (string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", false
+	}
+	if cmd := strings.ToLower(fields[0]); cmd != "u" && cmd != "undo" {
+		return "", false
+	}
+	if len(fields) == 1 {
+		return defaultUndoReason, true
+	}
+	return strings.Join(fields[1:], " "), true
+}
+
+// drain drops flags typed before the action they would apply to.
+func (rv *reviewer) drain() {
+	for {
+		select {
+		case <-rv.flags:
+		default:
+			return
+		}
+	}
+}
+
+// await waits up to window for a flag and returns its reason ("" when the
+// window passes unflagged).
+func (rv *reviewer) await(ctx context.Context, window time.Duration) (string, error) {
+	t := time.NewTimer(window)
+	defer t.Stop()
+	select {
+	case reason := <-rv.flags:
+		return reason, nil
+	case <-t.C:
+		return "", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// reviewAction gives the reviewer window to flag the action just applied and,
+// when flagged, takes it back and records the undo against decisionID. The
+// card comes back on screen and the loop decides it again.
+func reviewAction(
+	ctx context.Context,
+	profileIdx int,
+	rv *reviewer,
+	window time.Duration,
+	client *apps.GenericClient,
+	store persistence.Store,
+	session *analytics.Session,
+	decisionID int64,
+) error {
+	log.Printf("profile %d: type \"u [reason]\" within %s to undo", profileIdx, window)
+	reason, err := rv.await(ctx, window)
+	if err != nil || reason == "" {
+		return err
+	}
+	if _, err := client.Act(ctx, domain.AppAction{Kind: domain.AppActionUndo}); err != nil {
+		return fmt.Errorf("undo action: %w", err)
+	}
+	log.Printf("profile %d: undone (%s)", profileIdx, reason)
+	if session != nil {
+		session.Inc("undos", 1)
+	}
+	if err := store.SaveUndo(ctx, decisionID, reason); err != nil {
+		return fmt.Errorf("store undo: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func main() {
+	var (
+		appName    = flag.String("app", string(domain.Bumble), "App name; the adapter must support messaging")
+		adapterDir = flag.String("adapter-dir", apps.DefaultAdapterDir, "Directory of declarative adapter YAMLs loaded at startup")
+		loginURL   = flag.String("login-url", "", "App entry URL; defaults to adapter's value when empty")
+		headless   = flag.Bool("headless", false, "Run browser headless")
+		control    = flag.String("remote-url", "", "DevTools address of a running browser: port, host:port or ws:// URL (optional). If empty, launches a new browser")
+		outPath    = flag.String("out", "out/conversations.json", "File to write the conversations JSON to (- for stdout)")
+		limit      = flag.Int("limit", 0, "Read at most this many conversations (0 = all)")
+		timeout    = flag.Duration("timeout", 5*time.Minute, "Overall timeout")
+	)
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := apps.New(apps.Config{
+		AppName:    domain.AppName(*appName),
+		EntryURL:   *loginURL,
+		Headless:   *headless,
+		ControlURL: *control,
+		AdapterDir: *adapterDir,
+	})
+	if err != nil {
+		log.Fatalf("dump_conversations: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Open(ctx); err != nil {
+		log.Fatalf("open: %v", err)
+	}
+
+	convs, err := dump(ctx, client, *limit)
+	if err != nil {
+		log.Fatalf("dump_conversations: %v", err)
+	}
+
+	payload, err := json.MarshalIndent(convs, "", "  ")
+	if err != nil {
+		log.Fatalf("marshal conversations: %v", err)
+	}
+	if *outPath == "-" {
+		fmt.Println(string(payload))
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(*outPath), 0o755); err != nil {
+		log.Fatalf("prepare output dir: %v", err)
+	}
+	if err := os.WriteFile(*outPath, payload, 0o644); err != nil {
+		log.Fatalf("write output: %v", err)
+	}
+	fmt.Printf("wrote %d conversation(s) to %s\n", len(convs), *outPath)
+}
+
+// dump lists the conversations and reads each one in turn. A chat that fails
+// to open or read keeps its list entry (name and preview) and is logged.
+func dump(ctx context.Context, client *apps.GenericClient, limit int) ([]domain.Conversation, error) {
+	list, err := client.ListConversations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list conversations: %w", err)
+	}
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	out := make([]domain.Conversation, 0, len(list))
+	for _, c := range list {
+		if err := client.OpenConversation(ctx, c.ID); err != nil {
+			log.Printf("conversation %s: %v", c.ID, err)
+			out = append(out, c)
+			continue
+		}
+		full, err := client.ReadConversation(ctx)
+		if err != nil {
+			log.Printf("conversation %s: %v", c.ID, err)
+			out = append(out, c)
+			continue
+		}
+		full.Preview = c.Preview
+		if full.Name == "" {
+			full.Name = c.Name
+		}
+		out = append(out, *full)
+		log.Printf("conversation %s: %d message(s)", c.ID, len(full.Messages))
+	}
+	return out, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/swipeassist/internal/vault"
+)
+
+func main() {
+	var (
+		vaultPath     = flag.String("vault", "secrets/vault.bin", "Encrypted vault file; created when missing")
+		appName       = flag.String("app", string(domain.Bumble), "App the credentials are for")
+		phone         = flag.String("phone", "", "Phone number to log in with (international format)")
+		email         = flag.String("email", "", "Email to log in with, for apps that offer it")
+		forgetSession = flag.Bool("forget-session", false, "Drop the saved session cookies so the next run logs in again")
+	)
+	flag.Parse()
+
+	pass := os.Getenv(vault.PassphraseEnv)
+	if pass == "" {
+		log.Fatalf("login_vault: set $%s to the vault passphrase", vault.PassphraseEnv)
+	}
+	v, err := vault.Open(*vaultPath, pass)
+	if err != nil {
+		log.Fatalf("login_vault: %v", err)
+	}
+
+	app := domain.AppName(strings.ToUpper(strings.TrimSpace(*appName)))
+	creds := domain.Credentials{Phone: strings.TrimSpace(*phone), Email: strings.TrimSpace(*email)}
+	changed := false
+	if !creds.Empty() {
+		v.SetCredentials(app, creds)
+		changed = true
+	}
+	if *forgetSession {
+		v.SetCookies(app, nil)
+		changed = true
+	}
+	if changed {
+		if err := v.Save(); err != nil {
+			log.Fatalf("login_vault: save: %v", err)
+		}
+	}
+
+	have, ok := v.Credentials(app)
+	if !ok {
+		fmt.Printf("%s: no credentials in %s\n", app, *vaultPath)
+		return
+	}
+	fmt.Printf("%s: phone %s, email %s, %d session cookie(s) in %s\n",
+		app, mask(have.Phone), mask(have.Email), len(v.Cookies(app)), *vaultPath)
+}
+
+// mask keeps the last few characters so the account can be recognised.
+func mask(s string) string {
+	if s == "" {
+		return "-"
+	}
+	const keep = 3
+	if len(s) <= keep {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-keep) + s[len(s)-keep:]
+}
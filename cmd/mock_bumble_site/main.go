@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/bumble/mocksite"
+)
+
+func main() {
+	var (
+		addr         = flag.String("addr", "127.0.0.1:8089", "Listen address")
+		profilesPath = flag.String("profiles", "", "Optional JSON file with the synthetic profiles; generated when empty")
+		count        = flag.Int("count", 10, "Number of generated profiles when -profiles is empty")
+		likeQuota    = flag.Int("like-quota", 0, "Likes/SuperSwipes allowed before the out-of-likes view (0 = unlimited)")
+		interstitial = flag.String("interstitial", "", "Title of a dismissable dialog shown over the deck at start (empty = none)")
+		quotaReset   = flag.Duration("quota-reset", time.Minute, "How long the like quota stays used up")
+		loginPhone   = flag.String("login-phone", "", "Put /app behind a sign-in form that accepts this phone number (empty = no login)")
+		loginCode    = flag.String("login-code", "123456", "Login code accepted with -login-phone")
+	)
+	flag.Parse()
+
+	profiles := mocksite.DefaultProfiles(*count)
+	if *profilesPath != "" {
+		loaded, err := mocksite.LoadProfiles(*profilesPath)
+		if err != nil {
+			log.Fatalf("load profiles: %v", err)
+		}
+		profiles = loaded
+	}
+
+	srv, err := mocksite.NewServer(profiles)
+	if err != nil {
+		log.Fatalf("init mock site: %v", err)
+	}
+	srv.SetLikeQuota(*likeQuota, *quotaReset)
+	srv.SetInterstitial(*interstitial)
+	if *loginPhone != "" {
+		srv.RequireLogin(*loginPhone, *loginCode)
+		log.Printf("mock bumble: sign in with %s, code %s", *loginPhone, *loginCode)
+	}
+
+	log.Printf("mock bumble: serving %d profile(s) at http://%s/app", len(profiles), *addr)
+	if err := http.ListenAndServe(*addr, srv.Handler()); err != nil {
+		log.Fatalf("mock bumble: %v", err)
+	}
+}
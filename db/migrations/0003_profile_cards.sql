@@ -0,0 +1,12 @@
+-- Store profile card facts (name, age, location, badges) read from the page.
+
+CREATE TABLE profile_cards (
+    id BIGSERIAL PRIMARY KEY,
+    profile_key TEXT,
+    app TEXT NOT NULL,
+    card JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX profile_cards_profile_key_idx ON profile_cards (profile_key);
+CREATE INDEX profile_cards_created_at_idx ON profile_cards (created_at);
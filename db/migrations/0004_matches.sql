@@ -0,0 +1,16 @@
+-- Store matches announced by the app right after a like, tied to the decision
+-- that produced them.
+
+CREATE TABLE matches (
+    id BIGSERIAL PRIMARY KEY,
+    decision_id BIGINT REFERENCES decisions (id),
+    profile_key TEXT,
+    app TEXT NOT NULL,
+    name TEXT,
+    mode TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX matches_profile_key_idx ON matches (profile_key);
+CREATE INDEX matches_decision_id_idx ON matches (decision_id);
+CREATE INDEX matches_created_at_idx ON matches (created_at);
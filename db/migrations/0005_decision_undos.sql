@@ -0,0 +1,13 @@
+-- Allow UNDO as an action kind and record undo events against the decision
+-- whose action was taken back.
+
+ALTER TYPE app_action_kind ADD VALUE IF NOT EXISTS 'UNDO';
+
+CREATE TABLE decision_undos (
+    id BIGSERIAL PRIMARY KEY,
+    decision_id BIGINT NOT NULL REFERENCES decisions (id),
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX decision_undos_decision_id_idx ON decision_undos (decision_id);
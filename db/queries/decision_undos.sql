@@ -0,0 +1,18 @@
+-- Store and fetch undo events for decisions.
+
+-- name: InsertDecisionUndo :one
+INSERT INTO decision_undos (
+    decision_id,
+    reason
+) VALUES ($1, $2)
+RETURNING id, decision_id, reason, created_at;
+
+-- name: ListUndosByDecision :many
+SELECT
+    id,
+    decision_id,
+    reason,
+    created_at
+FROM decision_undos
+WHERE decision_id = $1
+ORDER BY created_at;
@@ -0,0 +1,25 @@
+-- Store and fetch matches.
+
+-- name: InsertMatch :one
+INSERT INTO matches (
+    decision_id,
+    profile_key,
+    app,
+    name,
+    mode
+) VALUES ($1, $2, $3, $4, $5)
+RETURNING id, decision_id, profile_key, app, name, mode, created_at;
+
+-- name: ListMatchesByProfile :many
+SELECT
+    id,
+    decision_id,
+    profile_key,
+    app,
+    name,
+    mode,
+    created_at
+FROM matches
+WHERE profile_key = $1
+ORDER BY created_at DESC;
+
@@ -0,0 +1,20 @@
+-- Store and fetch ProfileCard snapshots.
+
+-- name: InsertProfileCard :one
+INSERT INTO profile_cards (
+    profile_key,
+    app,
+    card
+) VALUES ($1, $2, $3)
+RETURNING id, profile_key, app, card, created_at;
+
+-- name: ListProfileCardsByProfile :many
+SELECT
+    id,
+    profile_key,
+    app,
+    card,
+    created_at
+FROM profile_cards
+WHERE profile_key = $1
+ORDER BY created_at DESC;
@@ -20,6 +20,9 @@ type DecisionContext struct {
 
 	BehaviourTraits *domain.BehaviourTraits    `json:"behaviour_traits,omitempty"`
 	PhotoPersona    *domain.PhotoPersonaBundle `json:"photo_persona_bundle,omitempty"`
+	// ProfileCard is read from the page, so policies can filter on it without
+	// waiting for extraction.
+	ProfileCard *domain.ProfileCard `json:"profile_card,omitempty"`
 
 	// optional: useful for logs, replay, debugging
 	ProfileKey string `json:"profile_key,omitempty"`
@@ -4,4 +4,5 @@ type AppName string
 
 const (
 	Bumble AppName = "BUMBLE"
+	Tinder AppName = "TINDER"
 )
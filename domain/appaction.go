@@ -6,6 +6,8 @@ const (
 	AppActionPass       AppActionType = "PASS"
 	AppActionLike       AppActionType = "LIKE"
 	AppActionSuperSwipe AppActionType = "SUPERSWIPE"
+	// AppActionUndo takes back the previous action (Bumble's Backtrack).
+	AppActionUndo AppActionType = "UNDO"
 )
 
 type AppAction struct {
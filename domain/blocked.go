@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// BlockKind names a state in which the app won't let the run keep swiping.
+type BlockKind string
+
+const (
+	BlockOutOfLikes BlockKind = "out_of_likes" // free likes used up
+	BlockPaywall    BlockKind = "paywall"      // premium upsell in the way
+	BlockDeckEmpty  BlockKind = "deck_empty"   // no more people nearby
+)
+
+// BlockedError reports a blocking state detected on screen. Match it with
+// errors.As.
+type BlockedError struct {
+	Kind BlockKind
+	// ResetAt is when the app says the block lifts; zero when none is shown.
+	ResetAt time.Time
+	// Detail is the app's own wording, when it could be read.
+	Detail string
+	// Err is the failure that led to the check, if any.
+	Err error
+}
+
+func (e *BlockedError) Error() string {
+	msg := "app blocked: " + string(e.Kind)
+	if !e.ResetAt.IsZero() {
+		msg += fmt.Sprintf(" until %s", e.ResetAt.Format(time.RFC3339))
+	}
+	if e.Detail != "" {
+		msg += fmt.Sprintf(" (%s)", e.Detail)
+	}
+	return msg
+}
+
+func (e *BlockedError) Unwrap() error { return e.Err }
@@ -0,0 +1,17 @@
+package domain
+
+// Conversation is a chat with one match. Lists carry only the preview;
+// Messages is filled when the conversation is read.
+type Conversation struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Preview  string    `json:"preview,omitempty"` // last message as listed
+	Messages []Message `json:"messages,omitempty"`
+}
+
+// Message is one chat bubble, oldest first within a Conversation.
+type Message struct {
+	FromMe bool   `json:"from_me"`
+	Text   string `json:"text"`
+	SentAt string `json:"sent_at,omitempty"` // as displayed
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+// Credentials identify the account an adapter signs in with. Phone wins when
+// both are set and the app offers it.
+type Credentials struct {
+	Phone string `json:"phone,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+func (c Credentials) Empty() bool { return c.Phone == "" && c.Email == "" }
+
+// OTPFunc returns the one-time code the app sent during login. It blocks
+// until the code is available or ctx is done.
+type OTPFunc func(ctx context.Context) (string, error)
@@ -0,0 +1,32 @@
+package domain
+
+import "errors"
+
+// ErrMessageNotSent is wrapped by a MessageActor whose action was applied but
+// whose message then failed; the action must not be repeated.
+var ErrMessageNotSent = errors.New("action applied but message not sent")
+
+// MatchMode says what to do with the "It's a match" overlay.
+type MatchMode string
+
+const (
+	MatchDismiss MatchMode = "dismiss" // close it and keep swiping
+	MatchChat    MatchMode = "chat"    // open the conversation and stop swiping
+)
+
+// Match is a match the app announced right after an action.
+type Match struct {
+	Name string    `json:"name,omitempty"` // as shown on the overlay
+	Mode MatchMode `json:"mode"`           // how the overlay was handled
+}
+
+// ActResult is what happened after an action was applied.
+type ActResult struct {
+	Match *Match // nil unless the action produced a match
+	// MessageSent is set when the action's Message went out with it; false
+	// with a Message means the app didn't offer a composer and the plain
+	// action was applied.
+	MessageSent bool
+	// MessageErr is why the Message failed after the action was applied.
+	MessageErr error
+}
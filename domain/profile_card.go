@@ -0,0 +1,22 @@
+package domain
+
+import "errors"
+
+// ErrProfileUnidentified is wrapped by adapters when the card on screen has
+// nothing to key it by: still rendering, or not a person (an ad or promo).
+var ErrProfileUnidentified = errors.New("cannot identify current profile")
+
+// ProfileCard holds the hard facts shown on a profile card, read straight
+// from the page (no LLM involved). Zero values mean "not shown".
+type ProfileCard struct {
+	Name      string `json:"name"`
+	Age       int    `json:"age,omitempty"`
+	Distance  string `json:"distance,omitempty"` // as displayed, e.g. "3 km away"
+	Location  string `json:"location,omitempty"`
+	Job       string `json:"job,omitempty"`
+	Education string `json:"education,omitempty"`
+
+	Verified       bool `json:"verified"`
+	RecentlyActive bool `json:"recently_active"`
+	Premium        bool `json:"premium"`
+}
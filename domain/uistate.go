@@ -0,0 +1,15 @@
+package domain
+
+// UIState is the screen an app shows, as classified by its adapter.
+type UIState string
+
+const (
+	UIStateUnknown   UIState = "unknown" // nothing recognised
+	UIStateLoading   UIState = "loading"
+	UIStateCard      UIState = "card" // deck with a card to act on
+	UIStateDeckEmpty UIState = "deck_empty"
+	UIStateBlocked   UIState = "blocked" // out of likes or paywall; see BlockedError
+	UIStateModal     UIState = "modal"   // dismissable dialog or interstitial
+	UIStateLoggedOut UIState = "logged_out"
+	UIStateError     UIState = "error" // the app's own error page
+)
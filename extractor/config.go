@@ -3,6 +3,7 @@ package extractor
 import (
 	"time"
 
+	"github.com/vd09-projects/swipeassist/internal/retry"
 	"github.com/vd09-projects/vision-traits/config"
 )
 
@@ -15,5 +16,7 @@ type ExtractorConfig struct {
 
 	// Optional retry configuration. Zero values fall back to sensible defaults.
 	RetryAttempts int
-	RetryDelay    time.Duration
+	RetryDelay    time.Duration // first backoff; doubles per try
+	// RetryObserver, when set, sees every extraction attempt (for metrics).
+	RetryObserver retry.Observer
 }
@@ -0,0 +1,49 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/vision-traits/traits"
+)
+
+// BehaviourReader reads the current profile's text from the page
+// (apps.GenericClient implements it).
+type BehaviourReader interface {
+	ReadBehaviour(ctx context.Context) (*domain.BehaviourTraits, error)
+}
+
+// DOMExtractor reads behaviour traits from the DOM instead of screenshots and
+// delegates photo persona extraction to an underlying extractor. A card with
+// no readable text falls back to the underlying ExtractBehaviour.
+type DOMExtractor struct {
+	reader         BehaviourReader
+	innerExtractor Extractor
+}
+
+func NewDOMExtractor(reader BehaviourReader, inner Extractor) (*DOMExtractor, error) {
+	if reader == nil {
+		return nil, fmt.Errorf("behaviour reader is nil")
+	}
+	if inner == nil {
+		return nil, fmt.Errorf("inner extractor is nil")
+	}
+	return &DOMExtractor{reader: reader, innerExtractor: inner}, nil
+}
+
+// ExtractBehaviour ignores imagePaths unless the DOM read comes back empty.
+func (d *DOMExtractor) ExtractBehaviour(ctx context.Context, profileKey string, imagePaths []string) (*domain.BehaviourTraits, error) {
+	bt, err := d.reader.ReadBehaviour(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read behaviour from dom: %w", err)
+	}
+	if bt == nil || (bt.QASections == nil && bt.ProfileTags == nil && bt.RawText == nil) {
+		return d.innerExtractor.ExtractBehaviour(ctx, profileKey, imagePaths)
+	}
+	return bt, nil
+}
+
+func (d *DOMExtractor) ExtractPhotoPersona(ctx context.Context, profileKey string, imagePaths []string) (*traits.ExtractedTraits, error) {
+	return d.innerExtractor.ExtractPhotoPersona(ctx, profileKey, imagePaths)
+}
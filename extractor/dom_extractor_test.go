@@ -0,0 +1,88 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/vision-traits/traits"
+)
+
+type fakeReader struct {
+	resp  *domain.BehaviourTraits
+	err   error
+	calls int
+}
+
+func (f *fakeReader) ReadBehaviour(context.Context) (*domain.BehaviourTraits, error) {
+	f.calls++
+	return f.resp, f.err
+}
+
+func TestDOMExtractorReadsBehaviourFromDOM(t *testing.T) {
+	t.Parallel()
+
+	reader := &fakeReader{resp: &domain.BehaviourTraits{
+		GlobalConfidence: 100,
+		RawText:          &domain.RawTextBlock{Confidence: 100, Lines: []string{"hi"}},
+	}}
+	inner := &fakeExtractor{personaResp: &traits.ExtractedTraits{GlobalConfidence: 20}}
+
+	ext, err := NewDOMExtractor(reader, inner)
+	if err != nil {
+		t.Fatalf("NewDOMExtractor returned error: %v", err)
+	}
+
+	bh, err := ext.ExtractBehaviour(context.Background(), "p1", []string{"a.png"})
+	if err != nil {
+		t.Fatalf("ExtractBehaviour returned error: %v", err)
+	}
+	if bh != reader.resp {
+		t.Fatalf("expected DOM traits, got %#v", bh)
+	}
+	if inner.behaviourCalls != 0 {
+		t.Fatalf("inner behaviour extractor should not be called, got %d calls", inner.behaviourCalls)
+	}
+
+	ph, err := ext.ExtractPhotoPersona(context.Background(), "p1", []string{"b.png"})
+	if err != nil {
+		t.Fatalf("ExtractPhotoPersona returned error: %v", err)
+	}
+	if inner.personaCalls != 1 || ph.GlobalConfidence != 20 {
+		t.Fatalf("expected persona delegated to inner, got calls=%d resp=%#v", inner.personaCalls, ph)
+	}
+}
+
+func TestDOMExtractorFallsBackWhenCardHasNoText(t *testing.T) {
+	t.Parallel()
+
+	reader := &fakeReader{resp: &domain.BehaviourTraits{GlobalConfidence: 100}}
+	inner := &fakeExtractor{behaviourResp: &domain.BehaviourTraits{GlobalConfidence: 7}}
+
+	ext, err := NewDOMExtractor(reader, inner)
+	if err != nil {
+		t.Fatalf("NewDOMExtractor returned error: %v", err)
+	}
+
+	bh, err := ext.ExtractBehaviour(context.Background(), "p1", []string{"a.png"})
+	if err != nil {
+		t.Fatalf("ExtractBehaviour returned error: %v", err)
+	}
+	if bh.GlobalConfidence != 7 || inner.behaviourCalls != 1 {
+		t.Fatalf("expected fallback to inner, got calls=%d resp=%#v", inner.behaviourCalls, bh)
+	}
+}
+
+func TestDOMExtractorPropagatesReadError(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	ext, err := NewDOMExtractor(&fakeReader{err: boom}, &fakeExtractor{})
+	if err != nil {
+		t.Fatalf("NewDOMExtractor returned error: %v", err)
+	}
+	if _, err := ext.ExtractBehaviour(context.Background(), "p1", nil); !errors.Is(err, boom) {
+		t.Fatalf("expected read error, got %v", err)
+	}
+}
@@ -2,14 +2,17 @@ package extractor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/swipeassist/internal/retry"
 	"github.com/vd09-projects/swipeassist/utils"
 	"github.com/vd09-projects/vision-traits/traits"
 )
@@ -17,6 +20,9 @@ import (
 const (
 	defaultRetryAttempts = 3
 	defaultRetryDelay    = 250 * time.Millisecond
+	retryMaxDelay        = 5 * time.Second
+	retryJitter          = 0.2
+	retryBudget          = 2 * time.Minute
 )
 
 type traitsPathExtractor interface {
@@ -27,8 +33,7 @@ type VisionExtractor struct {
 	behaviourTr traitsPathExtractor
 	personaTr   traitsPathExtractor
 
-	retryAttempts int
-	retryDelay    time.Duration
+	retry retry.Policy
 }
 
 func NewVisionExtractor(eCfg *ExtractorConfig) (Extractor, error) {
@@ -68,10 +73,17 @@ func NewVisionExtractor(eCfg *ExtractorConfig) (Extractor, error) {
 	}
 
 	return &VisionExtractor{
-		behaviourTr:   bTr,
-		personaTr:     pTr,
-		retryAttempts: retryAttempts,
-		retryDelay:    retryDelay,
+		behaviourTr: bTr,
+		personaTr:   pTr,
+		retry: retry.Policy{
+			Attempts:  retryAttempts,
+			BaseDelay: retryDelay,
+			MaxDelay:  retryMaxDelay,
+			Jitter:    retryJitter,
+			Budget:    retryBudget,
+			Classify:  classifyLLMError,
+			Observe:   eCfg.RetryObserver,
+		},
 	}, nil
 }
 
@@ -103,46 +115,46 @@ func (e *VisionExtractor) extractWithRetry(
 	extractor traitsPathExtractor,
 	imagePaths []string,
 ) (traits.ExtractedTraits, error) {
-	attempts := e.retryAttempts
-	if attempts <= 0 {
-		attempts = defaultRetryAttempts
-	}
-
-	delay := e.retryDelay
-	if delay < 0 {
-		delay = 0
-	}
-
-	var lastErr error
-	var lastRes traits.ExtractedTraits
-
-	for i := 0; i < attempts; i++ {
-		if err := ctx.Err(); err != nil {
-			return lastRes, err
-		}
-
-		res, err := extractor.ExtractFromPaths(ctx, imagePaths)
-		lastRes = res
-		if err == nil {
-			return res, nil
-		}
-
-		lastErr = err
-		if i == attempts-1 {
-			break
-		}
+	var res traits.ExtractedTraits
+	err := e.retry.Do(ctx, "vision_extract", func() error {
+		var err error
+		res, err = extractor.ExtractFromPaths(ctx, imagePaths)
+		return err
+	})
+	return res, err
+}
 
-		fmt.Println("Retrying the extract from VisionExtractor; Attempt No.", i + 1)
-		if err := utils.SleepCtx(ctx, delay); err != nil {
-			return lastRes, err
+// httpStatus finds the HTTP status in a provider error message, e.g.
+// "status code: 401", "HTTP 400" or "429 Too Many Requests".
+var httpStatus = regexp.MustCompile(`(?i:status(?: ?code)?|http(?:/[\d.]+)?)\W{0,3}([1-5]\d\d)\b|\b([1-5]\d\d) [A-Z][a-z]`)
+
+// classifyLLMError makes client errors fatal: a bad key, a bad request or a
+// spent quota fails the same way on every try. Timeouts and rate limits
+// (408, 429 without "quota") and server errors stay transient, as does
+// anything without a status.
+func classifyLLMError(err error) retry.Class {
+	if retry.DefaultClassify(err) == retry.Fatal {
+		return retry.Fatal
+	}
+	code := 0
+	var sc interface{ StatusCode() int }
+	if errors.As(err, &sc) {
+		code = sc.StatusCode()
+	} else if m := httpStatus.FindStringSubmatch(err.Error()); m != nil {
+		code, _ = strconv.Atoi(m[1] + m[2])
+	}
+	switch {
+	case code == 408:
+		return retry.Transient
+	case code == 429:
+		if strings.Contains(strings.ToLower(err.Error()), "quota") {
+			return retry.Fatal
 		}
+		return retry.Transient
+	case code >= 400 && code < 500:
+		return retry.Fatal
 	}
-
-	if lastErr == nil {
-		lastErr = fmt.Errorf("vision extraction failed after %d attempts", attempts)
-	}
-
-	return lastRes, lastErr
+	return retry.Transient
 }
 
 func mapToBehaviourTraits(in *traits.ExtractedTraits) *domain.BehaviourTraits {
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/vd09-projects/swipeassist/domain"
+	"github.com/vd09-projects/swipeassist/internal/retry"
 	"github.com/vd09-projects/vision-traits/traits"
 )
 
@@ -153,9 +154,8 @@ func TestVisionExtractorRetriesAndSucceeds(t *testing.T) {
 	}
 
 	e := &VisionExtractor{
-		behaviourTr:   mockTraits,
-		retryAttempts: 2,
-		retryDelay:    0,
+		behaviourTr: mockTraits,
+		retry:       retry.Policy{Attempts: 2},
 	}
 
 	got, err := e.ExtractBehaviour(context.Background(), "", []string{imgPath})
@@ -187,9 +187,8 @@ func TestVisionExtractorRetriesAndFails(t *testing.T) {
 	}
 
 	e := &VisionExtractor{
-		behaviourTr:   mockTraits,
-		retryAttempts: 2,
-		retryDelay:    0,
+		behaviourTr: mockTraits,
+		retry:       retry.Policy{Attempts: 2},
 	}
 
 	got, err := e.ExtractBehaviour(context.Background(), "", []string{imgPath})
@@ -207,6 +206,55 @@ func TestVisionExtractorRetriesAndFails(t *testing.T) {
 	}
 }
 
+type statusError int
+
+func (e statusError) Error() string   { return "provider error" }
+func (e statusError) StatusCode() int { return int(e) }
+
+func TestClassifyLLMError(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		want retry.Class
+	}{
+		{errors.New("error, status code: 401, message: invalid api key"), retry.Fatal},
+		{errors.New("POST /v1/responses: 400 Bad Request"), retry.Fatal},
+		{errors.New("status code: 429, message: You exceeded your current quota"), retry.Fatal},
+		{errors.New("status code: 429, message: rate limit reached"), retry.Transient},
+		{errors.New("HTTP 503"), retry.Transient},
+		{errors.New("read 412 bytes: connection reset"), retry.Transient},
+		{statusError(403), retry.Fatal},
+		{statusError(408), retry.Transient},
+		{context.Canceled, retry.Fatal},
+	} {
+		if got := classifyLLMError(tc.err); got != tc.want {
+			t.Errorf("classifyLLMError(%q) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestVisionExtractorFailsFastOnClientError(t *testing.T) {
+	t.Parallel()
+
+	imgPath := filepath.Join(t.TempDir(), "img.png")
+	if err := os.WriteFile(imgPath, []byte("stub"), 0o600); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+	mockTraits := &fakeTraitsExtractor{t: t, err: errors.New("status code: 401, message: invalid api key")}
+	e := &VisionExtractor{
+		behaviourTr: mockTraits,
+		retry:       retry.Policy{Attempts: 3, Classify: classifyLLMError},
+	}
+
+	if _, err := e.ExtractBehaviour(context.Background(), "", []string{imgPath}); err == nil {
+		t.Fatal("expected ExtractBehaviour to fail")
+	}
+	if mockTraits.callCount != 1 {
+		t.Fatalf("expected one call for a client error, got %d", mockTraits.callCount)
+	}
+}
+
 func TestMapPhotosToPersonaBundle(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,100 @@
+package imaging
+
+import (
+	"image"
+	_ "image/jpeg" // screenshots may be captured as JPEG
+	"math/bits"
+)
+
+// dhashW x dhashH is the grid DHash samples; one bit per horizontal pair.
+const (
+	dhashW = 9
+	dhashH = 8
+)
+
+// DHash returns a 64-bit difference hash of the image at path. Frames that
+// look the same hash within a few bits of each other regardless of size or
+// re-encoding; compare them with HashDistance.
+func DHash(path string) (uint64, error) {
+	img, err := decodeFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return dhash(img), nil
+}
+
+func dhash(img image.Image) uint64 {
+	var grid [dhashH][dhashW]uint32
+	b := img.Bounds()
+	for gy := 0; gy < dhashH; gy++ {
+		y0, y1 := span(b.Min.Y, b.Dy(), gy, dhashH)
+		for gx := 0; gx < dhashW; gx++ {
+			x0, x1 := span(b.Min.X, b.Dx(), gx, dhashW)
+			grid[gy][gx] = meanLuma(img, x0, y0, x1, y1)
+		}
+	}
+
+	var h uint64
+	for gy := 0; gy < dhashH; gy++ {
+		for gx := 0; gx < dhashW-1; gx++ {
+			h <<= 1
+			if grid[gy][gx] > grid[gy][gx+1] {
+				h |= 1
+			}
+		}
+	}
+	return h
+}
+
+// span returns the [lo, hi) pixel range of cell i of n along an axis, never
+// empty so tiny images still hash.
+func span(origin, size, i, n int) (int, int) {
+	lo := origin + i*size/n
+	hi := origin + (i+1)*size/n
+	if hi <= lo {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+func meanLuma(img image.Image, x0, y0, x1, y1 int) uint32 {
+	var sum, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			sum += uint64(299*r+587*g+114*b) / 1000
+			n++
+		}
+	}
+	return uint32(sum / n)
+}
+
+// HashDistance is the number of differing bits between two hashes.
+func HashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// FrameDeduper flags frames that look like one already kept.
+type FrameDeduper struct {
+	// MaxDistance is the largest HashDistance still counted as the same
+	// frame.
+	MaxDistance int
+
+	kept []uint64
+}
+
+// Check hashes the frame at path and reports whether it duplicates an
+// earlier kept frame. Frames that are not duplicates are kept.
+func (f *FrameDeduper) Check(path string) (dup bool, err error) {
+	h, err := DHash(path)
+	if err != nil {
+		return false, err
+	}
+	for _, k := range f.kept {
+		if HashDistance(h, k) <= f.MaxDistance {
+			return true, nil
+		}
+	}
+	f.kept = append(f.kept, h)
+	return false, nil
+}
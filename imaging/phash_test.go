@@ -0,0 +1,101 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeGradient writes a w x h grey ramp, dark to light left to right, or
+// light to dark when reverse is set.
+func writeGradient(t *testing.T, path string, w, h int, reverse bool) {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8(x * 255 / (w - 1))
+			if reverse {
+				v = 255 - v
+			}
+			img.SetGray(x, y, color.Gray{Y: v})
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create %s: %v", path, err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("encode %s: %v", path, err)
+	}
+}
+
+func TestDHashIgnoresScale(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	small, large, flipped := filepath.Join(dir, "s.png"), filepath.Join(dir, "l.png"), filepath.Join(dir, "f.png")
+	writeGradient(t, small, 90, 80, false)
+	writeGradient(t, large, 360, 320, false)
+	writeGradient(t, flipped, 90, 80, true)
+
+	hs, err := DHash(small)
+	if err != nil {
+		t.Fatalf("DHash returned error: %v", err)
+	}
+	hl, err := DHash(large)
+	if err != nil {
+		t.Fatalf("DHash returned error: %v", err)
+	}
+	hf, err := DHash(flipped)
+	if err != nil {
+		t.Fatalf("DHash returned error: %v", err)
+	}
+
+	if d := HashDistance(hs, hl); d != 0 {
+		t.Fatalf("rescaled frame distance = %d, want 0", d)
+	}
+	if d := HashDistance(hs, hf); d != 64 {
+		t.Fatalf("reversed frame distance = %d, want 64", d)
+	}
+}
+
+func TestFrameDeduperKeepsDistinctFrames(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	a, b, c := filepath.Join(dir, "a.png"), filepath.Join(dir, "b.png"), filepath.Join(dir, "c.png")
+	writeGradient(t, a, 90, 80, false)
+	writeGradient(t, b, 90, 80, true)
+	writeGradient(t, c, 180, 160, false)
+
+	f := &FrameDeduper{MaxDistance: 4}
+	for _, tc := range []struct {
+		path string
+		dup  bool
+	}{
+		{a, false},
+		{b, false},
+		{c, true}, // same picture as a
+		{b, true}, // unchanged frame
+	} {
+		dup, err := f.Check(tc.path)
+		if err != nil {
+			t.Fatalf("Check(%s) returned error: %v", tc.path, err)
+		}
+		if dup != tc.dup {
+			t.Fatalf("Check(%s) = %v, want %v", filepath.Base(tc.path), dup, tc.dup)
+		}
+	}
+}
+
+func TestDHashMissingFile(t *testing.T) {
+	t.Parallel()
+
+	if _, err := DHash(filepath.Join(t.TempDir(), "missing.png")); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+}
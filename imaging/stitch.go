@@ -0,0 +1,69 @@
+// Package imaging holds small image helpers for captured screenshots.
+package imaging
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+)
+
+// StitchVertical stacks the images at paths top to bottom, left aligned, on a
+// white canvas as wide as the widest one, and writes the result to out as PNG.
+func StitchVertical(out string, paths ...string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("stitch: no images")
+	}
+	imgs := make([]image.Image, 0, len(paths))
+	width, height := 0, 0
+	for _, p := range paths {
+		img, err := decodeFile(p)
+		if err != nil {
+			return err
+		}
+		b := img.Bounds()
+		width = max(width, b.Dx())
+		height += b.Dy()
+		imgs = append(imgs, img)
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	y := 0
+	for _, img := range imgs {
+		b := img.Bounds()
+		draw.Draw(canvas, image.Rect(0, y, b.Dx(), y+b.Dy()), img, b.Min, draw.Over)
+		y += b.Dy()
+	}
+
+	if dir := filepath.Dir(out); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, canvas); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func decodeFile(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return img, nil
+}
@@ -0,0 +1,72 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePNG(t *testing.T, path string, w, h int, c color.Color) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create %s: %v", path, err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("encode %s: %v", path, err)
+	}
+}
+
+func TestStitchVerticalStacksImages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	red, blue := filepath.Join(dir, "a.png"), filepath.Join(dir, "b.png")
+	writePNG(t, red, 40, 10, color.RGBA{R: 255, A: 255})
+	writePNG(t, blue, 20, 30, color.RGBA{B: 255, A: 255})
+
+	out := filepath.Join(dir, "out", "card.png")
+	if err := StitchVertical(out, red, blue); err != nil {
+		t.Fatalf("StitchVertical returned error: %v", err)
+	}
+
+	img, err := decodeFile(out)
+	if err != nil {
+		t.Fatalf("decode stitched image: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 40 || b.Dy() != 40 {
+		t.Fatalf("stitched size = %dx%d, want 40x40", b.Dx(), b.Dy())
+	}
+	checks := []struct {
+		x, y    int
+		r, g, b uint32
+	}{
+		{5, 5, 0xffff, 0, 0},             // first image
+		{5, 25, 0, 0, 0xffff},            // second image
+		{35, 25, 0xffff, 0xffff, 0xffff}, // padding right of the narrower image
+	}
+	for _, c := range checks {
+		r, g, b, _ := img.At(c.x, c.y).RGBA()
+		if r != c.r || g != c.g || b != c.b {
+			t.Fatalf("pixel (%d,%d) = %x,%x,%x; want %x,%x,%x", c.x, c.y, r, g, b, c.r, c.g, c.b)
+		}
+	}
+}
+
+func TestStitchVerticalNeedsImages(t *testing.T) {
+	t.Parallel()
+
+	if err := StitchVertical(filepath.Join(t.TempDir(), "out.png")); err == nil {
+		t.Fatalf("expected error for no images")
+	}
+}
@@ -18,6 +18,7 @@ const (
 	AppActionKindPASS       AppActionKind = "PASS"
 	AppActionKindLIKE       AppActionKind = "LIKE"
 	AppActionKindSUPERSWIPE AppActionKind = "SUPERSWIPE"
+	AppActionKindUNDO       AppActionKind = "UNDO"
 )
 
 func (e *AppActionKind) Scan(src interface{}) error {
@@ -125,6 +126,13 @@ type Decision struct {
 	CreatedAt     pgtype.Timestamptz   `json:"created_at"`
 }
 
+type DecisionUndo struct {
+	ID         int64              `json:"id"`
+	DecisionID int64              `json:"decision_id"`
+	Reason     string             `json:"reason"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type LlmRequest struct {
 	ID              int64              `json:"id"`
 	Kind            ExtractionKind     `json:"kind"`
@@ -150,6 +158,16 @@ type LlmRequestMedium struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type Match struct {
+	ID         int64              `json:"id"`
+	DecisionID *int64             `json:"decision_id"`
+	ProfileKey *string            `json:"profile_key"`
+	App        domain.AppName     `json:"app"`
+	Name       *string            `json:"name"`
+	Mode       domain.MatchMode   `json:"mode"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type PhotoPersonaResponse struct {
 	ID          int64                     `json:"id"`
 	RequestID   int64                     `json:"request_id"`
@@ -157,3 +175,11 @@ type PhotoPersonaResponse struct {
 	RawResponse []byte                    `json:"raw_response"`
 	CreatedAt   pgtype.Timestamptz        `json:"created_at"`
 }
+
+type ProfileCard struct {
+	ID         int64              `json:"id"`
+	ProfileKey *string            `json:"profile_key"`
+	App        domain.AppName     `json:"app"`
+	Card       domain.ProfileCard `json:"card"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
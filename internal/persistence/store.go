@@ -13,7 +13,13 @@ import (
 // Call Close when finished.
 type Store interface {
 	SaveBehaviour(ctx context.Context, profileKey string, app domain.AppName, traits *domain.BehaviourTraits) error
-	SaveDecision(ctx context.Context, profileKey string, decision *policies.Decision) error
+	// SaveDecision returns the stored decision's id (0 when nothing is stored).
+	SaveDecision(ctx context.Context, profileKey string, decision *policies.Decision) (int64, error)
+	// SaveMatch records a match against the decision that produced it.
+	SaveMatch(ctx context.Context, decisionID int64, profileKey string, app domain.AppName, match *domain.Match) error
+	// SaveUndo records that the decision's action was taken back.
+	SaveUndo(ctx context.Context, decisionID int64, reason string) error
+	SaveProfileCard(ctx context.Context, profileKey string, app domain.AppName, card *domain.ProfileCard) error
 	Close(ctx context.Context) error
 }
 
@@ -38,8 +44,19 @@ type NoopStore struct{}
 func (NoopStore) SaveBehaviour(_ context.Context, _ string, _ domain.AppName, _ *domain.BehaviourTraits) error {
 	return nil
 }
-func (NoopStore) SaveDecision(_ context.Context, _ string, _ *policies.Decision) error { return nil }
-func (NoopStore) Close(_ context.Context) error                                        { return nil }
+func (NoopStore) SaveDecision(_ context.Context, _ string, _ *policies.Decision) (int64, error) {
+	return 0, nil
+}
+func (NoopStore) SaveMatch(_ context.Context, _ int64, _ string, _ domain.AppName, _ *domain.Match) error {
+	return nil
+}
+func (NoopStore) SaveUndo(_ context.Context, _ int64, _ string) error {
+	return nil
+}
+func (NoopStore) SaveProfileCard(_ context.Context, _ string, _ domain.AppName, _ *domain.ProfileCard) error {
+	return nil
+}
+func (NoopStore) Close(_ context.Context) error { return nil }
 
 // DBStore persists entities through sqlc-generated queries.
 type DBStore struct {
@@ -59,15 +76,15 @@ func (s *DBStore) SaveBehaviour(ctx context.Context, profileKey string, app doma
 	return err
 }
 
-func (s *DBStore) SaveDecision(ctx context.Context, profileKey string, decision *policies.Decision) error {
+func (s *DBStore) SaveDecision(ctx context.Context, profileKey string, decision *policies.Decision) (int64, error) {
 	if decision == nil {
-		return nil
+		return 0, nil
 	}
 	var msgPtr *string
 	if decision.Action.Message != "" {
 		msgPtr = stringPtr(decision.Action.Message)
 	}
-	_, err := s.queries.InsertDecision(ctx, dbgen.InsertDecisionParams{
+	row, err := s.queries.InsertDecision(ctx, dbgen.InsertDecisionParams{
 		ProfileKey:    stringPtr(profileKey),
 		App:           decision.App,
 		PolicyName:    string(decision.PolicyName),
@@ -76,6 +93,51 @@ func (s *DBStore) SaveDecision(ctx context.Context, profileKey string, decision
 		Score:         decision.Score,
 		Reason:        decision.Reason,
 	})
+	return row.ID, err
+}
+
+func (s *DBStore) SaveMatch(ctx context.Context, decisionID int64, profileKey string, app domain.AppName, match *domain.Match) error {
+	if match == nil {
+		return nil
+	}
+	var decisionPtr *int64
+	if decisionID != 0 {
+		decisionPtr = &decisionID
+	}
+	var namePtr *string
+	if match.Name != "" {
+		namePtr = stringPtr(match.Name)
+	}
+	_, err := s.queries.InsertMatch(ctx, dbgen.InsertMatchParams{
+		DecisionID: decisionPtr,
+		ProfileKey: stringPtr(profileKey),
+		App:        app,
+		Name:       namePtr,
+		Mode:       match.Mode,
+	})
+	return err
+}
+
+func (s *DBStore) SaveUndo(ctx context.Context, decisionID int64, reason string) error {
+	if decisionID == 0 {
+		return nil
+	}
+	_, err := s.queries.InsertDecisionUndo(ctx, dbgen.InsertDecisionUndoParams{
+		DecisionID: decisionID,
+		Reason:     reason,
+	})
+	return err
+}
+
+func (s *DBStore) SaveProfileCard(ctx context.Context, profileKey string, app domain.AppName, card *domain.ProfileCard) error {
+	if card == nil {
+		return nil
+	}
+	_, err := s.queries.InsertProfileCard(ctx, dbgen.InsertProfileCardParams{
+		ProfileKey: stringPtr(profileKey),
+		App:        app,
+		Card:       *card,
+	})
 	return err
 }
 
@@ -0,0 +1,165 @@
+// Package retry runs operations under a retry policy: exponential backoff
+// with jitter, a time budget per operation and a transient/fatal split of
+// errors, with every attempt reported to an optional observer.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// Class says whether a failed attempt is worth repeating.
+type Class int
+
+const (
+	Transient Class = iota // may succeed on another try
+	Fatal                  // returned at once
+)
+
+func (c Class) String() string {
+	if c == Fatal {
+		return "fatal"
+	}
+	return "transient"
+}
+
+// Attempt is one try of an operation, as reported to an Observer.
+type Attempt struct {
+	Op       string
+	N        int           // 1-based
+	Err      error         // nil when the try succeeded
+	Class    Class         // of Err; Transient when Err is nil
+	Duration time.Duration // time spent in the try
+	// Delay is the wait before the next try; zero when none follows.
+	Delay time.Duration
+}
+
+// Observer is called after every attempt, on the caller's goroutine.
+type Observer func(Attempt)
+
+// Policy describes how an operation is retried. The zero value tries once.
+type Policy struct {
+	Attempts int // tries in total, including the first; <= 0 means 1
+
+	// BaseDelay is the wait before the second try; each later wait is
+	// Multiplier (default 2) times longer, up to MaxDelay when set.
+	BaseDelay  time.Duration
+	Multiplier float64
+	MaxDelay   time.Duration
+	// Jitter randomises each wait by up to this fraction either way (0..1),
+	// so callers that failed together don't retry together.
+	Jitter float64
+
+	// Budget caps the time spent on one operation: no wait or new try
+	// starts past it. A try already running is not cut short. Zero means
+	// no cap.
+	Budget time.Duration
+
+	// Classify sorts errors; nil uses DefaultClassify.
+	Classify func(error) Class
+	Observe  Observer
+}
+
+// permanentError marks an error as fatal for DefaultClassify.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so DefaultClassify treats it as fatal.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// DefaultClassify treats context cancellation and Permanent errors as fatal,
+// everything else as transient.
+func DefaultClassify(err error) Class {
+	var perm *permanentError
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded), errors.As(err, &perm):
+		return Fatal
+	}
+	return Transient
+}
+
+// Do calls fn until it succeeds, fails with a fatal error, or the attempts
+// or the budget run out; the last error is returned as is. op names the
+// operation for the observer. A cancelled ctx stops the retries and its
+// error is returned.
+func (p Policy) Do(ctx context.Context, op string, fn func() error) error {
+	attempts := max(p.Attempts, 1)
+	classify := p.Classify
+	if classify == nil {
+		classify = DefaultClassify
+	}
+	start := time.Now()
+	delay := p.BaseDelay
+
+	for n := 1; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		tryStart := time.Now()
+		err := fn()
+		at := Attempt{Op: op, N: n, Err: err, Duration: time.Since(tryStart)}
+		if err != nil {
+			at.Class = classify(err)
+		}
+		wait := p.jitter(delay)
+		retry := err != nil && at.Class == Transient && n < attempts &&
+			(p.Budget <= 0 || time.Since(start)+wait < p.Budget)
+		if retry {
+			at.Delay = wait
+		}
+		if p.Observe != nil {
+			p.Observe(at)
+		}
+		if !retry {
+			return err
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+		delay = p.next(delay)
+	}
+}
+
+func (p Policy) next(d time.Duration) time.Duration {
+	mult := p.Multiplier
+	if mult <= 0 {
+		mult = 2
+	}
+	d = time.Duration(float64(d) * mult)
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+func (p Policy) jitter(d time.Duration) time.Duration {
+	if p.Jitter <= 0 || d <= 0 {
+		return d
+	}
+	j := min(p.Jitter, 1)
+	return time.Duration(float64(d) * (1 + j*(2*rand.Float64()-1)))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDoBacksOffExponentially(t *testing.T) {
+	t.Parallel()
+
+	var seen []Attempt
+	p := Policy{Attempts: 4, BaseDelay: time.Millisecond, MaxDelay: 3 * time.Millisecond, Observe: func(a Attempt) { seen = append(seen, a) }}
+	flaky := errors.New("flaky")
+	calls := 0
+	err := p.Do(context.Background(), "click", func() error {
+		calls++
+		if calls < 4 {
+			return flaky
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	var delays []time.Duration
+	for _, a := range seen {
+		if a.Op != "click" {
+			t.Fatalf("attempt op = %q, want click", a.Op)
+		}
+		delays = append(delays, a.Delay)
+	}
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond, 0}
+	if !reflect.DeepEqual(delays, want) {
+		t.Fatalf("delays = %v, want %v", delays, want)
+	}
+	if seen[3].Err != nil || seen[3].N != 4 {
+		t.Fatalf("last attempt = %+v, want a 4th success", seen[3])
+	}
+}
+
+func TestDoStopsOnFatal(t *testing.T) {
+	t.Parallel()
+
+	missing := errors.New("selector not found")
+	p := Policy{
+		Attempts: 5,
+		Classify: func(err error) Class {
+			if errors.Is(err, missing) {
+				return Fatal
+			}
+			return DefaultClassify(err)
+		},
+	}
+	for _, fatal := range []error{missing, Permanent(errors.New("bad request")), context.Canceled} {
+		calls := 0
+		err := p.Do(context.Background(), "op", func() error { calls++; return fatal })
+		if !errors.Is(err, fatal) {
+			t.Fatalf("Do returned %v, want %v", err, fatal)
+		}
+		if calls != 1 {
+			t.Fatalf("%v retried: %d calls", fatal, calls)
+		}
+	}
+}
+
+func TestDoRespectsBudget(t *testing.T) {
+	t.Parallel()
+
+	p := Policy{Attempts: 100, BaseDelay: 20 * time.Millisecond, Budget: 50 * time.Millisecond}
+	calls := 0
+	last := errors.New("still failing")
+	err := p.Do(context.Background(), "op", func() error { calls++; return last })
+	if !errors.Is(err, last) {
+		t.Fatalf("Do returned %v, want %v", err, last)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2 within a 50ms budget", calls)
+	}
+}
+
+func TestDoJitterStaysInRange(t *testing.T) {
+	t.Parallel()
+
+	p := Policy{Jitter: 0.5}
+	for range 100 {
+		if d := p.jitter(100 * time.Millisecond); d < 50*time.Millisecond || d > 150*time.Millisecond {
+			t.Fatalf("jittered delay %s outside 50ms..150ms", d)
+		}
+	}
+}
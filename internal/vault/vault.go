@@ -0,0 +1,249 @@
+// Package vault keeps login credentials and session cookies in a local file
+// encrypted with a passphrase.
+//
+// The file is "SAVAULT1", the PBKDF2 iteration count (uint32, big endian), a
+// 16-byte salt and a 12-byte nonce, followed by the AES-256-GCM sealed JSON
+// contents. The header is authenticated along with the contents.
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+// PassphraseEnv is the environment variable the commands read the vault
+// passphrase from.
+const PassphraseEnv = "SWIPEASSIST_VAULT_PASSPHRASE"
+
+var (
+	// ErrBadPassphrase means the file did not decrypt: a wrong passphrase or
+	// a tampered file.
+	ErrBadPassphrase = errors.New("vault: wrong passphrase or corrupted file")
+	ErrNoPassphrase  = errors.New("vault: empty passphrase")
+)
+
+const (
+	magic     = "SAVAULT1"
+	saltSize  = 16
+	nonceSize = 12
+	keySize   = 32
+	headerLen = len(magic) + 4 + saltSize
+)
+
+// kdfIterations is used for files written from now on; files keep the count
+// they were written with.
+var kdfIterations = 600_000
+
+// A stored count outside these bounds is rejected before any key is derived:
+// too few makes the passphrase cheap to guess, too many stalls the open.
+const (
+	minKDFIterations = 1_000
+	maxKDFIterations = 10_000_000
+)
+
+// Entry is what the vault holds for one app.
+type Entry struct {
+	Credentials    domain.Credentials `json:"credentials"`
+	Cookies        []engine.Cookie    `json:"cookies,omitempty"`
+	CookiesSavedAt time.Time          `json:"cookies_saved_at,omitzero"`
+}
+
+// Vault is an open vault file. Changes stay in memory until Save.
+type Vault struct {
+	path       string
+	passphrase string
+
+	mu   sync.Mutex
+	apps map[domain.AppName]*Entry
+	now  func() time.Time
+}
+
+// Open decrypts the vault at path. A missing file is an empty vault, created
+// on the first Save.
+func Open(path, passphrase string) (*Vault, error) {
+	if passphrase == "" {
+		return nil, ErrNoPassphrase
+	}
+	v := &Vault{path: path, passphrase: passphrase, apps: map[domain.AppName]*Entry{}, now: time.Now}
+	buf, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return v, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	plain, err := open(buf, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(plain, &v.apps); err != nil {
+		return nil, fmt.Errorf("vault: decode %s: %w", path, err)
+	}
+	if v.apps == nil {
+		v.apps = map[domain.AppName]*Entry{}
+	}
+	return v, nil
+}
+
+func (v *Vault) Path() string { return v.path }
+
+// Credentials returns the account stored for app.
+func (v *Vault) Credentials(app domain.AppName) (domain.Credentials, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	e, ok := v.apps[app]
+	if !ok || e.Credentials.Empty() {
+		return domain.Credentials{}, false
+	}
+	return e.Credentials, true
+}
+
+func (v *Vault) SetCredentials(app domain.AppName, creds domain.Credentials) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.entry(app).Credentials = creds
+}
+
+// Cookies returns app's saved session cookies, leaving out expired ones.
+func (v *Vault) Cookies(app domain.AppName) []engine.Cookie {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	e, ok := v.apps[app]
+	if !ok {
+		return nil
+	}
+	now := v.now()
+	var out []engine.Cookie
+	for _, c := range e.Cookies {
+		if c.Expires.IsZero() || c.Expires.After(now) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// SetCookies replaces app's session cookies.
+func (v *Vault) SetCookies(app domain.AppName, cookies []engine.Cookie) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	e := v.entry(app)
+	e.Cookies = append([]engine.Cookie(nil), cookies...)
+	e.CookiesSavedAt = v.now()
+}
+
+func (v *Vault) entry(app domain.AppName) *Entry {
+	e, ok := v.apps[app]
+	if !ok {
+		e = &Entry{}
+		v.apps[app] = e
+	}
+	return e
+}
+
+// Save encrypts the vault with a fresh salt and nonce and replaces the file
+// atomically. The file is readable by its owner only.
+func (v *Vault) Save() error {
+	v.mu.Lock()
+	plain, err := json.Marshal(v.apps)
+	v.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	buf, err := seal(plain, v.passphrase, kdfIterations)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(v.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".vault-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), v.path)
+}
+
+func seal(plain []byte, passphrase string, iterations int) ([]byte, error) {
+	header := make([]byte, headerLen, headerLen+nonceSize+len(plain)+16)
+	copy(header, magic)
+	binary.BigEndian.PutUint32(header[len(magic):], uint32(iterations))
+	salt := header[len(magic)+4:]
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(passphrase, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+	out := append(header, nonce...)
+	return aead.Seal(out, nonce, plain, header), nil
+}
+
+func open(buf []byte, passphrase string) ([]byte, error) {
+	if len(buf) < headerLen+nonceSize || string(buf[:len(magic)]) != magic {
+		return nil, errors.New("vault: not a vault file")
+	}
+	header := buf[:headerLen]
+	iterations := int(binary.BigEndian.Uint32(header[len(magic):]))
+	if iterations < minKDFIterations || iterations > maxKDFIterations {
+		return nil, fmt.Errorf("vault: PBKDF2 iteration count %d outside %d..%d", iterations, minKDFIterations, maxKDFIterations)
+	}
+	salt := header[len(magic)+4:]
+	nonce := buf[headerLen : headerLen+nonceSize]
+
+	aead, err := newAEAD(passphrase, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := aead.Open(nil, nonce, buf[headerLen+nonceSize:], header)
+	if err != nil {
+		return nil, ErrBadPassphrase
+	}
+	return plain, nil
+}
+
+func newAEAD(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("vault: derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,138 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vd09-projects/swipeassist/apps/engine"
+	"github.com/vd09-projects/swipeassist/domain"
+)
+
+func init() { kdfIterations = 1000 } // keep the tests fast
+
+func TestVaultRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "vault.bin")
+	v, err := Open(path, "correct horse")
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	creds := domain.Credentials{Phone: "+447700900123"}
+	cookies := []engine.Cookie{{Name: "session", Value: "s3cret", Domain: ".bumble.com", Path: "/"}}
+	v.SetCredentials(domain.Bumble, creds)
+	v.SetCookies(domain.Bumble, cookies)
+	if err := v.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	if bytes.Contains(raw, []byte("s3cret")) || bytes.Contains(raw, []byte("+447700900123")) {
+		t.Fatalf("vault file holds plaintext secrets")
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("vault file mode = %v, %v; want 0600", fi.Mode().Perm(), err)
+	}
+
+	got, err := Open(path, "correct horse")
+	if err != nil {
+		t.Fatalf("reopen returned error: %v", err)
+	}
+	if c, ok := got.Credentials(domain.Bumble); !ok || c != creds {
+		t.Fatalf("Credentials = %+v, %v; want %+v", c, ok, creds)
+	}
+	if c := got.Cookies(domain.Bumble); !reflect.DeepEqual(c, cookies) {
+		t.Fatalf("Cookies = %+v, want %+v", c, cookies)
+	}
+	if _, ok := got.Credentials(domain.Tinder); ok {
+		t.Fatalf("expected no tinder credentials")
+	}
+}
+
+func TestVaultWrongPassphrase(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "vault.bin")
+	v, _ := Open(path, "right")
+	v.SetCredentials(domain.Bumble, domain.Credentials{Email: "me@example.com"})
+	if err := v.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if _, err := Open(path, "wrong"); !errors.Is(err, ErrBadPassphrase) {
+		t.Fatalf("expected ErrBadPassphrase, got %v", err)
+	}
+	if _, err := Open(path, ""); !errors.Is(err, ErrNoPassphrase) {
+		t.Fatalf("expected ErrNoPassphrase, got %v", err)
+	}
+}
+
+func TestVaultRejectsTamperedHeader(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "vault.bin")
+	v, _ := Open(path, "pass")
+	if err := v.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	raw, _ := os.ReadFile(path)
+	raw[len(magic)+4] ^= 0xff // flip a salt byte
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	if _, err := Open(path, "pass"); !errors.Is(err, ErrBadPassphrase) {
+		t.Fatalf("expected ErrBadPassphrase, got %v", err)
+	}
+}
+
+func TestVaultRejectsIterationCountOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "vault.bin")
+	v, _ := Open(path, "pass")
+	if err := v.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	raw, _ := os.ReadFile(path)
+	for _, n := range []uint32{0, 1 << 31} {
+		binary.BigEndian.PutUint32(raw[len(magic):], n)
+		if err := os.WriteFile(path, raw, 0o600); err != nil {
+			t.Fatalf("WriteFile returned error: %v", err)
+		}
+		if _, err := Open(path, "pass"); err == nil || !strings.Contains(err.Error(), "iteration count") {
+			t.Fatalf("Open with %d iterations: expected a range error, got %v", n, err)
+		}
+	}
+}
+
+func TestVaultCookiesSkipExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	v, _ := Open(filepath.Join(t.TempDir(), "vault.bin"), "pass")
+	v.now = func() time.Time { return now }
+	v.SetCookies(domain.Bumble, []engine.Cookie{
+		{Name: "old", Domain: ".bumble.com", Expires: now.Add(-time.Minute)},
+		{Name: "live", Domain: ".bumble.com", Expires: now.Add(time.Hour)},
+		{Name: "session", Domain: ".bumble.com"},
+	})
+
+	var names []string
+	for _, c := range v.Cookies(domain.Bumble) {
+		names = append(names, c.Name)
+	}
+	if want := []string{"live", "session"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("cookies = %v, want %v", names, want)
+	}
+}